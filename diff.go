@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"kubeRadar/pkg/drift"
+	"kubeRadar/pkg/excel"
+	"kubeRadar/pkg/snapshot"
+)
+
+// runDiff implements `kubeRadar diff [flags] <old snapshot> <new snapshot>`
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	output := fs.String("output", "kubeRadar_drift.xlsx", "Drift report file path")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: kubeRadar diff [--output drift.xlsx] <old snapshot> <new snapshot>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("diff expects exactly two snapshots, got %d", fs.NArg())
+	}
+
+	fmt.Fprintf(os.Stderr, "[kubeRadar] Loading snapshots %s and %s...\n", fs.Arg(0), fs.Arg(1))
+	old, err := snapshot.Load(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("Error loading %s: %v", fs.Arg(0), err)
+	}
+	new, err := snapshot.Load(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("Error loading %s: %v", fs.Arg(1), err)
+	}
+
+	result := drift.Compare(old, new)
+	printDrift(result)
+
+	report, err := excel.NewReport(*output)
+	if err != nil {
+		return fmt.Errorf("Error creating report: %v", err)
+	}
+	if err := report.GenerateDrift(result); err != nil {
+		return fmt.Errorf("Error generating drift report: %v", err)
+	}
+	fmt.Printf("Drift report generated successfully: %s\n", *output)
+	return nil
+}
+
+// printDrift writes the per-kind change counts and the added and resolved
+// findings to stderr
+func printDrift(result drift.Result) {
+	if len(result.Changes) == 0 {
+		fmt.Fprintln(os.Stderr, "[kubeRadar] Drift: no changes between the assessments")
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "[kubeRadar] Drift: %d changes\n", len(result.Changes))
+	fmt.Fprintf(os.Stderr, "  %-20s %7s %7s %8s\n", "Kind", "Added", "Removed", "Modified")
+	for _, s := range result.Summary {
+		if s.Added+s.Removed+s.Modified == 0 {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "  %-20s %7d %7d %8d\n", s.Kind, s.Added, s.Removed, s.Modified)
+	}
+
+	for _, c := range result.Changes {
+		if c.Kind != "Finding" || c.Change == drift.Modified {
+			continue
+		}
+		mark := "+"
+		if c.Change == drift.Removed {
+			mark = "-"
+		}
+		location := c.Name
+		if c.Namespace != "" {
+			location = fmt.Sprintf("%s (namespace %s)", c.Name, c.Namespace)
+		}
+		fmt.Fprintf(os.Stderr, "  %s finding %s\n", mark, location)
+	}
+
+	if len(result.Incomplete) > 0 {
+		fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: %s were not fully collected in one of the assessments, some additions and removals may be collection gaps\n",
+			strings.Join(result.Incomplete, ", "))
+	}
+	fmt.Fprintln(os.Stderr, "[kubeRadar] See the Drift sheet for details")
+}
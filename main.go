@@ -5,12 +5,19 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"kubeRadar/pkg/collector"
 	"kubeRadar/pkg/excel"
+	"kubeRadar/pkg/html"
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/snapshot"
 
 	"github.com/briandowns/spinner"
+	"sigs.k8s.io/yaml"
 )
 
 func main() {
@@ -48,36 +55,269 @@ func main() {
           @@@@@@@@@@@@@@@@@@@@@@%#############################%%@@@@@@@@@@@@@@@@@@@@@@@@
 		            kubeRadar - Kubernetes Reconnaissance Tool`)
 
-	kubeconfig := flag.String("kubeconfig", "", "Path to kubeconfig file")
-	outputFile := flag.String("output", "kubeRadar_assessment.xlsx", "Output Excel file path")
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := runDiff(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "who-can" {
+		if err := runWhoCan(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "what-can" {
+		if err := runWhatCan(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	kubeconfig := flag.String("kubeconfig", "", "Path to kubeconfig file (defaults to $KUBECONFIG, then ~/.kube/config)")
+	kubeContext := flag.String("context", "", "Kubeconfig context to scan (defaults to the current context)")
+	allContexts := flag.Bool("all-contexts", false, "Scan every context in the kubeconfig")
+	contextList := flag.String("contexts", "", "Comma-separated list of kubeconfig contexts to scan")
+	outputFile := flag.String("output", "kubeRadar_assessment.xlsx", "Output file path")
+	format := flag.String("format", "xlsx", "Output format: xlsx, html, json or yaml")
+	inputFile := flag.String("input", "", "Load assessment data from a JSON or YAML snapshot instead of collecting from a cluster")
+	defaults := collector.DefaultOptions()
+	qps := flag.Float64("qps", float64(defaults.QPS), "Maximum API requests per second")
+	burst := flag.Int("burst", defaults.Burst, "Maximum burst of API requests above --qps")
+	workers := flag.Int("workers", defaults.Workers, "Number of collection phases run concurrently")
+	pageSize := flag.Int64("page-size", defaults.PageSize, "Items requested per List call (0 disables pagination)")
+	dumpPath := flag.String("dump", "", "Collect from a directory or .tar.gz of kubectl/must-gather YAML or JSON dumps instead of a live cluster")
+	outputDir := flag.String("output-dir", "", "Write timestamped reports into this directory instead of --output")
+	keep := flag.Int("keep", 0, "With --output-dir, keep only the newest N reports (0 keeps all)")
+	inspectSecrets := flag.Bool("inspect-secrets", false, "Read Secret payloads to report their exact type and key names (by default only Secret metadata is listed)")
+	printRBAC := flag.Bool("print-rbac", false, "Print the minimal read-only ClusterRole kubeRadar needs and exit")
 	flag.Parse()
 
-	// Use default kubeconfig if not specified
-	if *kubeconfig == "" {
-		homeDir, err := os.UserHomeDir()
+	if *printRBAC {
+		out, err := yaml.Marshal(collector.ReaderClusterRole("kuberadar-reader"))
+		if err != nil {
+			log.Fatalf("Error encoding ClusterRole: %v", err)
+		}
+		fmt.Print(string(out))
+		return
+	}
+
+	switch *format {
+	case "xlsx", "html", snapshot.FormatJSON, snapshot.FormatYAML:
+	default:
+		log.Fatalf("Unsupported output format %q (expected xlsx, html, json or yaml)", *format)
+	}
+
+	// Match the default output extension to the requested format
+	outputSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "output" {
+			outputSet = true
+		}
+	})
+	if !outputSet && *format != "xlsx" {
+		*outputFile = fmt.Sprintf("kubeRadar_assessment.%s", *format)
+	}
+
+	if *outputDir != "" {
+		if err := os.MkdirAll(*outputDir, 0o750); err != nil {
+			log.Fatalf("Error creating output directory: %v", err)
+		}
+		// Prune once the new report exists, so a failed run never loses old ones
+		defer func(output string) {
+			if *keep <= 0 {
+				return
+			}
+			removed, err := pruneReports(*outputDir, output, *keep)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: failed to prune old reports: %v\n", err)
+			}
+			if len(removed) > 0 {
+				fmt.Fprintf(os.Stderr, "[kubeRadar] Removed %d old report files from %s\n", len(removed), *outputDir)
+			}
+		}(*outputFile)
+		*outputFile = timestampedOutputPath(*outputDir, *outputFile, time.Now())
+	}
+
+	opts := collector.Options{
+		QPS:      float32(*qps),
+		Burst:    *burst,
+		Workers:  *workers,
+		PageSize: *pageSize,
+
+		InspectSecrets: *inspectSecrets,
+	}
+
+	if *inputFile != "" {
+		fmt.Fprintf(os.Stderr, "[kubeRadar] Loading snapshot %s...\n", *inputFile)
+		data, err := snapshot.Load(*inputFile)
+		if err != nil {
+			log.Fatalf("Error loading snapshot: %v", err)
+		}
+		if err := writeOutput(data, *format, *outputFile); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *dumpPath != "" {
+		fmt.Fprintf(os.Stderr, "[kubeRadar] Loading cluster dump %s...\n", *dumpPath)
+		c, err := collector.NewOfflineCollector(*dumpPath, opts)
 		if err != nil {
-			log.Fatalf("Error getting user home directory: %v", err)
+			log.Fatalf("Error initializing collector: %v", err)
+		}
+		data, err := collect(c)
+		if err != nil {
+			log.Fatalf("Error collecting data: %v", err)
+		}
+		if err := writeOutput(data, *format, *outputFile); err != nil {
+			log.Fatal(err)
 		}
-		*kubeconfig = fmt.Sprintf("%s/.kube/config", homeDir)
+		return
 	}
 
-	// Initialize collector
-	c, err := collector.NewCollector(*kubeconfig)
+	contexts, err := selectContexts(*kubeconfig, *kubeContext, *allContexts, *contextList)
 	if err != nil {
-		log.Fatalf("Error initializing collector: %v", err)
+		log.Fatalf("Error selecting kubeconfig contexts: %v", err)
 	}
 
-	// Collect all data
+	// Single cluster: one report at the requested output path
+	if len(contexts) == 1 {
+		opts.Context = contexts[0]
+		c, err := collector.NewCollector(*kubeconfig, opts)
+		if err != nil {
+			log.Fatalf("Error initializing collector: %v", err)
+		}
+		data, err := collect(c)
+		if err != nil {
+			log.Fatalf("Error collecting data: %v", err)
+		}
+		if err := writeOutput(data, *format, *outputFile); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Multiple clusters: one report per context plus a combined workbook
+	clusters := make([]excel.ClusterReport, 0, len(contexts))
+	for _, name := range contexts {
+		fmt.Fprintf(os.Stderr, "[kubeRadar] Scanning context %s...\n", name)
+		opts.Context = name
+		c, err := collector.NewCollector(*kubeconfig, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: skipping context %s: %v\n", name, err)
+			continue
+		}
+		data, err := collect(c)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: skipping context %s: %v\n", name, err)
+			continue
+		}
+		if err := writeOutput(data, *format, clusterOutputPath(*outputFile, name)); err != nil {
+			log.Fatal(err)
+		}
+		clusters = append(clusters, excel.ClusterReport{Name: name, Data: data})
+	}
+
+	if *format == "xlsx" && len(clusters) > 0 {
+		fmt.Fprintln(os.Stderr, "[kubeRadar] Generating combined Excel report...")
+		if err := excel.GenerateCombined(*outputFile, clusters); err != nil {
+			log.Fatalf("Error generating combined report: %v", err)
+		}
+		fmt.Printf("Combined report for %d clusters generated successfully: %s\n", len(clusters), *outputFile)
+	}
+}
+
+// selectContexts resolves the context flags into the list of contexts to
+// scan. An empty name stands for the kubeconfig's current context.
+func selectContexts(kubeconfig, kubeContext string, allContexts bool, contextList string) ([]string, error) {
+	set := 0
+	for _, selected := range []bool{kubeContext != "", allContexts, contextList != ""} {
+		if selected {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, fmt.Errorf("--context, --all-contexts and --contexts are mutually exclusive")
+	}
+
+	switch {
+	case allContexts:
+		names, _, err := collector.Contexts(kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no contexts found in kubeconfig")
+		}
+		return names, nil
+	case contextList != "":
+		names := make([]string, 0)
+		for _, name := range strings.Split(contextList, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("--contexts contains no context names")
+		}
+		return names, nil
+	default:
+		return []string{kubeContext}, nil
+	}
+}
+
+// clusterOutputPath derives a per-cluster file name from the output path,
+// e.g. report.xlsx and context prod/eu become report_prod_eu.xlsx
+func clusterOutputPath(output, context string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, context)
+	ext := filepath.Ext(output)
+	return fmt.Sprintf("%s_%s%s", strings.TrimSuffix(output, ext), safe, ext)
+}
+
+// collect runs the collector and prints its coverage summary
+func collect(c *collector.Collector) (*models.AssessmentData, error) {
 	fmt.Fprintln(os.Stderr, "[kubeRadar] Collecting cluster data...")
 	data, err := c.CollectAll()
 	if err != nil {
-		log.Fatalf("Error collecting data: %v", err)
+		return nil, err
+	}
+	printCoverage(data.Coverage)
+	return data, nil
+}
+
+// writeOutput writes the data as an Excel or HTML report or a snapshot
+func writeOutput(data *models.AssessmentData, format, outputFile string) error {
+	if format == "html" {
+		fmt.Fprintln(os.Stderr, "[kubeRadar] Generating HTML report...")
+		if err := html.NewReport(outputFile).Generate(data); err != nil {
+			return fmt.Errorf("Error generating report: %v", err)
+		}
+		fmt.Fprintln(os.Stderr, "[kubeRadar] Assessment complete!")
+		fmt.Printf("Assessment report generated successfully: %s\n", outputFile)
+		return nil
+	}
+
+	if format != "xlsx" {
+		fmt.Fprintf(os.Stderr, "[kubeRadar] Writing %s snapshot...\n", format)
+		if err := snapshot.Save(outputFile, format, data); err != nil {
+			return fmt.Errorf("Error writing snapshot: %v", err)
+		}
+		fmt.Fprintln(os.Stderr, "[kubeRadar] Assessment complete!")
+		fmt.Printf("Assessment snapshot written successfully: %s\n", outputFile)
+		return nil
 	}
+
 	fmt.Fprintln(os.Stderr, "[kubeRadar] Generating Excel report...")
 	// Generate Excel report
-	report, err := excel.NewReport(*outputFile)
+	report, err := excel.NewReport(outputFile)
 	if err != nil {
-		log.Fatalf("Error creating report: %v", err)
+		return fmt.Errorf("Error creating report: %v", err)
 	}
 
 	s := spinner.New(spinner.CharSets[14], 100*time.Millisecond)
@@ -86,10 +326,43 @@ func main() {
 
 	fmt.Fprintln(os.Stderr, "[kubeRadar] Writing data to Excel file...")
 	if err := report.Generate(data); err != nil {
-		log.Fatalf("Error generating report: %v", err)
+		s.Stop()
+		return fmt.Errorf("Error generating report: %v", err)
 	}
 
 	s.Stop()
 	fmt.Fprintln(os.Stderr, "[kubeRadar] Assessment complete!")
-	fmt.Printf("Assessment report generated successfully: %s\n", *outputFile)
+	fmt.Printf("Assessment report generated successfully: %s\n", outputFile)
+	return nil
+}
+
+// printCoverage writes a summary of what could not be collected to stderr
+func printCoverage(coverage models.CoverageAssessment) {
+	incomplete := coverage.Incomplete()
+	if len(incomplete) == 0 {
+		fmt.Fprintf(os.Stderr, "[kubeRadar] Collection coverage: all %d kinds collected completely\n", len(coverage.Kinds))
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "[kubeRadar] Collection coverage: %d of %d kinds incomplete\n", len(incomplete), len(coverage.Kinds))
+	for _, kind := range incomplete {
+		reasons := make(map[string]int)
+		for _, e := range coverage.Errors {
+			if e.Kind == kind.Kind {
+				reasons[e.Reason]++
+			}
+		}
+		summary := make([]string, 0, len(reasons))
+		for reason, count := range reasons {
+			summary = append(summary, fmt.Sprintf("%s x%d", reason, count))
+		}
+		sort.Strings(summary)
+		if kind.FailedNamespaces > 0 {
+			fmt.Fprintf(os.Stderr, "  - %s: %s, %d collected, %d namespaces unreadable (%s)\n",
+				kind.Kind, kind.Status, kind.Collected, kind.FailedNamespaces, strings.Join(summary, ", "))
+		} else {
+			fmt.Fprintf(os.Stderr, "  - %s: %s (%s)\n", kind.Kind, kind.Status, strings.Join(summary, ", "))
+		}
+	}
+	fmt.Fprintln(os.Stderr, "[kubeRadar] See the Collection Coverage sheet for details")
 }
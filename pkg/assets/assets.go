@@ -0,0 +1,10 @@
+// Package assets embeds the static files shared by the report renderers, so
+// the binary works outside the source tree, e.g. in a container.
+package assets
+
+import _ "embed"
+
+// Logo is the kubeRadar logo in PNG format
+//
+//go:embed logo.png
+var Logo []byte
@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"kubeRadar/pkg/models"
+)
+
+// weakSignatureAlgorithms are signature algorithms relying on broken hashes
+var weakSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.MD2WithRSA:    true,
+	x509.MD5WithRSA:    true,
+	x509.SHA1WithRSA:   true,
+	x509.DSAWithSHA1:   true,
+	x509.ECDSAWithSHA1: true,
+}
+
+// parseCertificates decodes every certificate of a PEM bundle, such as the
+// tls.crt of a TLS Secret. Other PEM blocks, like private keys, are skipped.
+func parseCertificates(bundle []byte) ([]models.CertificateInfo, error) {
+	certs := make([]models.CertificateInfo, 0)
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return certs, fmt.Errorf("failed to parse certificate %d: %v", len(certs)+1, err)
+		}
+		certs = append(certs, convertCertificate(cert))
+	}
+	if len(certs) == 0 {
+		return certs, errors.New("no PEM certificate found")
+	}
+	return certs, nil
+}
+
+func convertCertificate(cert *x509.Certificate) models.CertificateInfo {
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses))
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	return models.CertificateInfo{
+		Subject:            cert.Subject.String(),
+		SANs:               sans,
+		Issuer:             cert.Issuer.String(),
+		NotBefore:          cert.NotBefore.UTC().String(),
+		NotAfter:           cert.NotAfter.UTC().String(),
+		KeyAlgorithm:       cert.PublicKeyAlgorithm.String(),
+		KeySize:            keySize(cert.PublicKey),
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		IsCA:               cert.IsCA,
+		SelfSigned:         selfSigned(cert),
+		WeakSignature:      weakSignatureAlgorithms[cert.SignatureAlgorithm],
+	}
+}
+
+// keySize returns the size of a public key in bits, or 0 for unknown types
+func keySize(key interface{}) int {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return k.N.BitLen()
+	case *ecdsa.PublicKey:
+		return k.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return 256
+	}
+	return 0
+}
+
+// selfSigned reports whether a certificate was issued by its own subject.
+// The signature itself is not verified, as Go refuses to check SHA-1
+// signatures, which are the ones worth reporting.
+func selfSigned(cert *x509.Certificate) bool {
+	if !bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return false
+	}
+	return len(cert.AuthorityKeyId) == 0 || bytes.Equal(cert.AuthorityKeyId, cert.SubjectKeyId)
+}
@@ -2,79 +2,266 @@ package collector
 
 import (
 	"context"
+	"fmt"
 	"kubeRadar/pkg/models"
-	"path/filepath"
+	"os"
+	"sort"
+	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
+	"k8s.io/client-go/tools/pager"
 )
 
-type Collector struct {
-	client *kubernetes.Clientset
-	config *rest.Config
+// Options tunes how the collector talks to the API server
+type Options struct {
+	Context  string  // kubeconfig context to use, empty for the current context
+	QPS      float32 // client-side request rate, 0 keeps the client-go default
+	Burst    int     // client-side request burst, 0 keeps the client-go default
+	Workers  int     // number of collection phases run concurrently
+	PageSize int64   // items requested per List call, 0 disables pagination
+
+	// InspectSecrets lets analyses read Secret payloads. Otherwise Secrets are
+	// listed as metadata only whenever a metadata client is available.
+	InspectSecrets bool
 }
 
-func NewCollector(kubeconfigPath string) (*Collector, error) {
-	if kubeconfigPath == "" {
-		if home := homedir.HomeDir(); home != "" {
-			kubeconfigPath = filepath.Join(home, ".kube", "config")
+// Contexts returns the context names found by the kubeconfig loading rules,
+// sorted by name, along with the current context
+func Contexts(kubeconfigPath string) ([]string, string, error) {
+	raw, err := newClientConfig(kubeconfigPath, "").RawConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	names := make([]string, 0, len(raw.Contexts))
+	for name := range raw.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, raw.CurrentContext, nil
+}
+
+// kubeconfigExists reports whether any file of the default loading rules exists
+func kubeconfigExists() bool {
+	for _, path := range clientcmd.NewDefaultClientConfigLoadingRules().GetLoadingPrecedence() {
+		if _, err := os.Stat(path); err == nil {
+			return true
 		}
 	}
+	return false
+}
 
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
-	if err != nil {
-		return nil, err
+func newClientConfig(kubeconfigPath, context string) clientcmd.ClientConfig {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		rules.ExplicitPath = kubeconfigPath
 	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: context}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+}
 
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, err
+// DefaultOptions returns options suitable for large clusters
+func DefaultOptions() Options {
+	return Options{
+		QPS:      50,
+		Burst:    100,
+		Workers:  5,
+		PageSize: 500,
 	}
+}
 
-	return &Collector{
-		client: clientset,
-		config: config,
-	}, nil
+type Collector struct {
+	client   kubernetes.Interface
+	metadata metadata.Interface
+	config   *rest.Config
+	opts     Options
+	coverage *coverageRecorder
+	now      func() time.Time
+
+	// objects of an offline dump that failed to convert, recorded in the
+	// coverage of their kind
+	dumpErrors []models.CollectionError
 }
 
-func (c *Collector) CollectAll() (*models.AssessmentData, error) {
-	ctx := context.Background()
+// Clients bundles the API clients a Collector reads from
+type Clients struct {
+	Kube kubernetes.Interface
+	// Metadata lists objects as PartialObjectMetadata. It is optional; without
+	// it Secrets are listed in full through Kube.
+	Metadata metadata.Interface
+}
 
-	clusterInfo, err := c.collectClusterInfo(ctx)
-	if err != nil {
-		return nil, err
+// NewCollectorForClients creates a collector on top of existing clients, such
+// as client-go's fake clientset. config is only used to report the API server.
+func NewCollectorForClients(clients Clients, config *rest.Config, opts Options) *Collector {
+	return &Collector{
+		client:   clients.Kube,
+		metadata: clients.Metadata,
+		config:   config,
+		opts:     opts,
+		now:      time.Now,
 	}
+}
 
-	rbac, err := c.collectRBACInfo(ctx)
-	if err != nil {
-		return nil, err
+// NewCollector creates a collector for the cluster selected by opts.Context.
+// kubeconfigPath overrides the standard loading rules, which otherwise honour
+// a multi-path KUBECONFIG and fall back to ~/.kube/config. When no kubeconfig
+// exists at all, the in-cluster service account configuration is used.
+func NewCollector(kubeconfigPath string, opts Options) (*Collector, error) {
+	var config *rest.Config
+	var err error
+	if kubeconfigPath == "" && opts.Context == "" && !kubeconfigExists() {
+		config, err = rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("no kubeconfig found and not running in a cluster: %v", err)
+		}
+		opts.Context = "in-cluster"
+	} else {
+		clientConfig := newClientConfig(kubeconfigPath, opts.Context)
+		config, err = clientConfig.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		if opts.Context == "" {
+			if raw, err := clientConfig.RawConfig(); err == nil {
+				opts.Context = raw.CurrentContext
+			}
+		}
+	}
+	if opts.QPS > 0 {
+		config.QPS = opts.QPS
+	}
+	if opts.Burst > 0 {
+		config.Burst = opts.Burst
 	}
 
-	workloads, err := c.collectWorkloadInfo(ctx)
+	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
-
-	network, err := c.collectNetworkInfo(ctx)
+	metadataClient, err := metadata.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
 
-	secrets, err := c.collectSecretInfo(ctx)
-	if err != nil {
+	return NewCollectorForClients(Clients{Kube: clientset, Metadata: metadataClient}, config, opts), nil
+}
+
+func (c *Collector) CollectAll() (*models.AssessmentData, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.coverage = newCoverageRecorder(c.dumpErrors)
+
+	var (
+		clusterInfo models.ClusterInfo
+		rbac        models.RBACAssessment
+		workloads   models.WorkloadAssessment
+		network     models.NetworkAssessment
+		secrets     models.SecretAssessment
+		configMaps  models.ConfigMapAssessment
+	)
+
+	// Every phase writes only its own result, so they can run in parallel
+	phases := []func(ctx context.Context) error{
+		func(ctx context.Context) (err error) {
+			clusterInfo, err = c.collectClusterInfo(ctx)
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			rbac, err = c.collectRBACInfo(ctx)
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			workloads, err = c.collectWorkloadInfo(ctx)
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			network, err = c.collectNetworkInfo(ctx)
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			secrets, err = c.collectSecretInfo(ctx)
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			configMaps, err = c.collectConfigMapInfo(ctx)
+			return err
+		},
+	}
+	if err := c.runPhases(ctx, cancel, phases); err != nil {
 		return nil, err
 	}
 
-	return &models.AssessmentData{
+	data := &models.AssessmentData{
 		ClusterInfo: clusterInfo,
 		RBAC:        rbac,
 		Workloads:   workloads,
 		Network:     network,
 		Secrets:     secrets,
-	}, nil
+		ConfigMaps:  configMaps,
+		Coverage:    c.coverage.result(),
+	}
+	sortAssessment(data)
+	return data, nil
+}
+
+// runPhases runs the phases on a pool of at most opts.Workers goroutines.
+// The first failing phase cancels the others and its error is returned.
+func (c *Collector) runPhases(ctx context.Context, cancel context.CancelFunc, phases []func(ctx context.Context) error) error {
+	workers := c.opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, workers)
+	for _, phase := range phases {
+		wg.Add(1)
+		go func(phase func(ctx context.Context) error) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+			if err := phase(ctx); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(phase)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// listAll pages through a List call with opts.PageSize items per request and
+// returns every item. T is the item type of the list, e.g. corev1.Pod.
+func listAll[T any](ctx context.Context, c *Collector, list pager.ListPageFunc) ([]T, error) {
+	p := pager.New(list)
+	p.PageSize = c.opts.PageSize
+
+	items := make([]T, 0)
+	err := p.EachListItem(ctx, metav1.ListOptions{}, func(obj runtime.Object) error {
+		item, ok := any(obj).(*T)
+		if !ok {
+			return fmt.Errorf("unexpected list item type %T", obj)
+		}
+		items = append(items, *item)
+		return nil
+	})
+	return items, err
 }
 
 func (c *Collector) collectClusterInfo(ctx context.Context) (models.ClusterInfo, error) {
@@ -83,20 +270,18 @@ func (c *Collector) collectClusterInfo(ctx context.Context) (models.ClusterInfo,
 		return models.ClusterInfo{}, err
 	}
 
-	nodes, err := c.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return models.ClusterInfo{}, err
-	}
+	nodes := listClusterScoped[corev1.Node](ctx, c, "Nodes", func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return c.client.CoreV1().Nodes().List(ctx, opts)
+	})
 
 	// Collect namespace information
-	namespaces, err := c.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return models.ClusterInfo{}, err
-	}
+	namespaces := listClusterScoped[corev1.Namespace](ctx, c, "Namespaces", func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return c.client.CoreV1().Namespaces().List(ctx, opts)
+	})
 
 	// Collect node details
 	nodeDetails := make([]models.NodeInfo, 0)
-	for _, node := range nodes.Items {
+	for _, node := range nodes {
 		nodeInfo := models.NodeInfo{
 			Name:             node.Name,
 			Version:          node.Status.NodeInfo.KubeletVersion,
@@ -120,11 +305,11 @@ func (c *Collector) collectClusterInfo(ctx context.Context) (models.ClusterInfo,
 
 	// Collect namespace details
 	namespaceDetails := make([]models.NamespaceInfo, 0)
-	for _, ns := range namespaces.Items {
+	for _, ns := range namespaces {
 		nsInfo := models.NamespaceInfo{
 			Name:      ns.Name,
 			Status:    string(ns.Status.Phase),
-			CreatedAt: ns.CreationTimestamp.String(),
+			CreatedAt: creationTime(ns.ObjectMeta),
 			Labels:    ns.Labels,
 		}
 		namespaceDetails = append(namespaceDetails, nsInfo)
@@ -132,16 +317,33 @@ func (c *Collector) collectClusterInfo(ctx context.Context) (models.ClusterInfo,
 
 	// Get platform info from nodes
 	platform := ""
-	if len(nodes.Items) > 0 {
-		platform = nodes.Items[0].Status.NodeInfo.OperatingSystem
+	if len(nodes) > 0 {
+		platform = nodes[0].Status.NodeInfo.OperatingSystem
 	}
 
 	return models.ClusterInfo{
-		Version:    version.String(),
-		NodeCount:  len(nodes.Items),
-		Platform:   platform,
-		Nodes:      nodeDetails,
-		Namespaces: namespaceDetails,
-		APIServer:  c.config.Host,
+		Version:     version.String(),
+		NodeCount:   len(nodes),
+		Platform:    platform,
+		CollectedAt: c.now().UTC().String(),
+		Nodes:       nodeDetails,
+		Namespaces:  namespaceDetails,
+		APIServer:   c.config.Host,
+		Context:     c.opts.Context,
 	}, nil
 }
+
+// creationTime formats an object's creation timestamp in UTC, so reports do
+// not depend on the local time zone of whoever runs the collection
+func creationTime(meta metav1.ObjectMeta) string {
+	return meta.CreationTimestamp.UTC().String()
+}
+
+// formatTime formats an optional timestamp like creationTime, or returns an
+// empty string when it is unset
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().String()
+}
@@ -0,0 +1,241 @@
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"kubeRadar/pkg/fixture"
+	"kubeRadar/pkg/models"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newFixtureCollector(clientset *fake.Clientset) *Collector {
+	opts := DefaultOptions()
+	opts.Context = "fixture"
+	opts.InspectSecrets = true
+	c := NewCollectorForClients(Clients{Kube: clientset}, &rest.Config{Host: "https://fixture.example.com:6443"}, opts)
+	c.now = func() time.Time { return fixture.CollectedAt }
+	return c
+}
+
+func coverageFor(t *testing.T, data *models.AssessmentData, kind string) models.KindCoverage {
+	t.Helper()
+	for _, k := range data.Coverage.Kinds {
+		if k.Kind == kind {
+			return k
+		}
+	}
+	t.Fatalf("no coverage recorded for %s", kind)
+	return models.KindCoverage{}
+}
+
+func TestCollectAllGolden(t *testing.T) {
+	data, err := newFixtureCollector(fixture.Clientset()).CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+	got, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode assessment: %v", err)
+	}
+	fixture.AssertGolden(t, "testdata/fixture.golden.json", append(got, '\n'))
+}
+
+func TestCollectAllPaginates(t *testing.T) {
+	clientset := fixture.Clientset()
+	calls := 0
+	// The fake tracker ignores Limit and Continue, so serve pages by hand
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		list, ok := action.(k8stesting.ListActionImpl)
+		if !ok {
+			return false, nil, nil
+		}
+		calls++
+		obj, err := clientset.Tracker().List(
+			schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			action.GetNamespace(),
+		)
+		if err != nil {
+			return true, nil, err
+		}
+		items := obj.(*corev1.PodList).Items
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].Namespace+"/"+items[i].Name < items[j].Namespace+"/"+items[j].Name
+		})
+
+		start, _ := strconv.Atoi(list.ListOptions.Continue)
+		end := start + int(list.ListOptions.Limit)
+		if list.ListOptions.Limit == 0 || end > len(items) {
+			end = len(items)
+		}
+		page := &corev1.PodList{Items: items[start:end]}
+		if end < len(items) {
+			page.Continue = strconv.Itoa(end)
+		}
+		return true, page, nil
+	})
+
+	c := newFixtureCollector(clientset)
+	c.opts.PageSize = 2
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+	if len(data.Workloads.Pods) != 3 {
+		t.Errorf("got %d pods, want 3", len(data.Workloads.Pods))
+	}
+	if calls != 2 {
+		t.Errorf("got %d pod list calls, want 2 pages", calls)
+	}
+}
+
+func TestCollectAllForbiddenFallsBack(t *testing.T) {
+	clientset := fixture.Clientset()
+	forbidden := func(resource string, allowed ...string) {
+		clientset.PrependReactor("list", resource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+			for _, namespace := range allowed {
+				if action.GetNamespace() == namespace {
+					return false, nil, nil
+				}
+			}
+			gr := schema.GroupResource{Group: action.GetResource().Group, Resource: resource}
+			return true, nil, apierrors.NewForbidden(gr, "", errors.New("access denied"))
+		})
+	}
+	// Secrets are readable in payments only, cluster roles not at all
+	forbidden("secrets", "payments")
+	forbidden("clusterroles")
+
+	data, err := newFixtureCollector(clientset).CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+
+	secrets := coverageFor(t, data, "Secrets")
+	if secrets.Status != models.CoveragePartial || secrets.Collected != 3 || secrets.FailedNamespaces != 2 {
+		t.Errorf("unexpected Secrets coverage: %+v", secrets)
+	}
+	if len(data.Secrets.Secrets) != 3 {
+		t.Errorf("got %d secrets, want the 3 in payments", len(data.Secrets.Secrets))
+	}
+	if roles := coverageFor(t, data, "ClusterRoles"); roles.Status != models.CoverageFailed {
+		t.Errorf("unexpected ClusterRoles coverage: %+v", roles)
+	}
+	if pods := coverageFor(t, data, "Pods"); pods.Status != models.CoverageComplete {
+		t.Errorf("unexpected Pods coverage: %+v", pods)
+	}
+	for _, e := range data.Coverage.Errors {
+		if e.Reason != "Forbidden" {
+			t.Errorf("unexpected error reason for %s: %s", e.Kind, e.Reason)
+		}
+	}
+}
+
+func TestCollectSecretsMetadataOnly(t *testing.T) {
+	clientset := fixture.Clientset()
+	opts := DefaultOptions()
+	c := NewCollectorForClients(Clients{Kube: clientset, Metadata: fixture.MetadataClient()}, &rest.Config{}, opts)
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+	for _, action := range clientset.Actions() {
+		if action.GetResource().Resource == "secrets" {
+			t.Errorf("Secret payloads were requested: %s", action.GetVerb())
+		}
+	}
+
+	types := make(map[string]string)
+	for _, s := range data.Secrets.Secrets {
+		types[s.Namespace+"/"+s.Name] = s.Type
+		if s.Keys != nil {
+			t.Errorf("%s/%s: keys collected without inspecting secrets", s.Namespace, s.Name)
+		}
+	}
+	want := map[string]string{
+		"default/legacy-tls":              "kubernetes.io/tls",
+		"default/registry":                "kubernetes.io/dockerconfigjson",
+		"kube-system/metrics-webhook-tls": "kubernetes.io/tls",
+		"payments/api-tls":                "kubernetes.io/tls",
+		"payments/api-token":              "kubernetes.io/service-account-token",
+		"payments/db-credentials":         "Opaque",
+	}
+	if len(types) != len(want) {
+		t.Errorf("got secrets %v, want %v", types, want)
+	}
+	for name, typ := range want {
+		if got, ok := types[name]; !ok || got != typ {
+			t.Errorf("%s: type = %q, want %q", name, got, typ)
+		}
+	}
+	if secrets := coverageFor(t, data, "Secrets"); secrets.Status != models.CoverageComplete || secrets.Collected != len(want) {
+		t.Errorf("unexpected Secrets coverage: %+v", secrets)
+	}
+}
+
+func TestCollectSecretsMetadataOnlyForbiddenFallsBack(t *testing.T) {
+	metadataClient := fixture.MetadataClient()
+	metadataClient.PrependReactor("list", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "payments" {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", errors.New("access denied"))
+	})
+	c := NewCollectorForClients(Clients{Kube: fixture.Clientset(), Metadata: metadataClient}, &rest.Config{}, DefaultOptions())
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+
+	secrets := coverageFor(t, data, "Secrets")
+	if secrets.Status != models.CoveragePartial || secrets.Collected != 3 || secrets.FailedNamespaces != 2 {
+		t.Errorf("unexpected Secrets coverage: %+v", secrets)
+	}
+	for _, s := range data.Secrets.Secrets {
+		if s.Type == "" {
+			t.Errorf("%s/%s: type not collected", s.Namespace, s.Name)
+		}
+	}
+}
+
+func TestCollectSecretsInspected(t *testing.T) {
+	opts := DefaultOptions()
+	opts.InspectSecrets = true
+	metadataClient := fixture.MetadataClient()
+	c := NewCollectorForClients(Clients{Kube: fixture.Clientset(), Metadata: metadataClient}, &rest.Config{}, opts)
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+	if n := len(metadataClient.Actions()); n != 0 {
+		t.Errorf("metadata client used %d times while inspecting secrets", n)
+	}
+	for _, s := range data.Secrets.Secrets {
+		if s.Name == "api-tls" && (s.Type != "kubernetes.io/tls" || strings.Join(s.Keys, ",") != "tls.crt,tls.key") {
+			t.Errorf("api-tls: type %q, keys %v", s.Type, s.Keys)
+		}
+	}
+}
+
+func TestCollectAllServerVersionError(t *testing.T) {
+	clientset := fixture.Clientset()
+	clientset.PrependReactor("get", "version", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	if _, err := newFixtureCollector(clientset).CollectAll(); err == nil {
+		t.Fatal("CollectAll succeeded without a server version")
+	}
+}
@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"context"
+	"sort"
+
+	"kubeRadar/pkg/credentials"
+	"kubeRadar/pkg/models"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/pager"
+)
+
+func (c *Collector) collectConfigMapInfo(ctx context.Context) (models.ConfigMapAssessment, error) {
+	configMapAssessment := models.ConfigMapAssessment{}
+
+	configMaps := listNamespaced[corev1.ConfigMap](ctx, c, "ConfigMaps", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+		}
+	})
+	for _, cm := range configMaps {
+		configMapAssessment.ConfigMaps = append(configMapAssessment.ConfigMaps, convertConfigMap(cm))
+	}
+
+	return configMapAssessment, nil
+}
+
+// convertConfigMap records the keys and size of a ConfigMap and scans its
+// values for credentials. The values themselves are dropped here.
+func convertConfigMap(cm corev1.ConfigMap) models.ConfigMapInfo {
+	info := models.ConfigMapInfo{
+		CommonInfo: models.CommonInfo{
+			Name:      cm.Name,
+			Namespace: cm.Namespace,
+			Labels:    cm.Labels,
+			CreatedAt: creationTime(cm.ObjectMeta),
+		},
+		Keys:                 make([]string, 0, len(cm.Data)+len(cm.BinaryData)),
+		SuspectedCredentials: make([]models.SuspectedCredential, 0),
+	}
+	for key, value := range cm.Data {
+		info.Keys = append(info.Keys, key)
+		info.Size += len(value)
+		if kinds := credentials.Detect(value); len(kinds) > 0 {
+			info.SuspectedCredentials = append(info.SuspectedCredentials, models.SuspectedCredential{Key: key, Kinds: kinds})
+		}
+	}
+	for key, value := range cm.BinaryData {
+		info.Keys = append(info.Keys, key)
+		info.Size += len(value)
+	}
+	sort.Strings(info.Keys)
+	sort.Slice(info.SuspectedCredentials, func(i, j int) bool {
+		return info.SuspectedCredentials[i].Key < info.SuspectedCredentials[j].Key
+	})
+	return info
+}
@@ -0,0 +1,122 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeRadar/pkg/models"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// sensitiveArgNames are parts of flag names whose values are redacted from
+// commands and arguments, like the environment variable filter of the report
+var sensitiveArgNames = []string{"password", "passwd", "secret", "token", "key", "credential"}
+
+const redacted = "<redacted>"
+
+func isSensitiveArg(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveArgNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactArgs copies a command or argument list, replacing the values of
+// flags that look like they carry credentials, in both the --flag=value and
+// the --flag value form
+func redactArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	redactNext := false
+	for _, arg := range args {
+		switch {
+		case redactNext && !strings.HasPrefix(arg, "-"):
+			out = append(out, redacted)
+			redactNext = false
+			continue
+		case strings.HasPrefix(arg, "-") && strings.Contains(arg, "="):
+			name, _, _ := strings.Cut(arg, "=")
+			if isSensitiveArg(name) {
+				arg = name + "=" + redacted
+			}
+		}
+		redactNext = strings.HasPrefix(arg, "-") && !strings.Contains(arg, "=") && isSensitiveArg(arg)
+		out = append(out, arg)
+	}
+	return out
+}
+
+// formatProbe formats a probe as its handler and target, e.g.
+// httpGet :8080/healthz, or returns an empty string when it is unset
+func formatProbe(p *corev1.Probe) string {
+	if p == nil {
+		return ""
+	}
+	h := p.ProbeHandler
+	switch {
+	case h.HTTPGet != nil:
+		return fmt.Sprintf("httpGet :%s%s", h.HTTPGet.Port.String(), h.HTTPGet.Path)
+	case h.TCPSocket != nil:
+		return fmt.Sprintf("tcpSocket :%s", h.TCPSocket.Port.String())
+	case h.GRPC != nil:
+		return fmt.Sprintf("grpc :%d", h.GRPC.Port)
+	case h.Exec != nil:
+		return "exec " + strings.Join(redactArgs(h.Exec.Command), " ")
+	}
+	return "unknown"
+}
+
+// configReferences lists the Secrets and ConfigMaps a container reads
+// through its environment
+func configReferences(container corev1.Container) []models.ConfigReference {
+	refs := make([]models.ConfigReference, 0)
+	for _, env := range container.Env {
+		if env.ValueFrom == nil {
+			continue
+		}
+		via := "env " + env.Name
+		if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+			refs = append(refs, models.ConfigReference{Kind: models.ReferenceSecret, Name: ref.Name, Key: ref.Key, Via: via})
+		}
+		if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+			refs = append(refs, models.ConfigReference{Kind: models.ReferenceConfigMap, Name: ref.Name, Key: ref.Key, Via: via})
+		}
+	}
+	for _, from := range container.EnvFrom {
+		if from.SecretRef != nil {
+			refs = append(refs, models.ConfigReference{Kind: models.ReferenceSecret, Name: from.SecretRef.Name, Via: "envFrom"})
+		}
+		if from.ConfigMapRef != nil {
+			refs = append(refs, models.ConfigReference{Kind: models.ReferenceConfigMap, Name: from.ConfigMapRef.Name, Via: "envFrom"})
+		}
+	}
+	return refs
+}
+
+// volumeReferences maps the name of each volume backed by Secrets or
+// ConfigMaps to the objects behind it
+func volumeReferences(volumes []corev1.Volume) map[string][]models.ConfigReference {
+	refs := make(map[string][]models.ConfigReference)
+	for _, v := range volumes {
+		via := "volume " + v.Name
+		switch {
+		case v.Secret != nil:
+			refs[v.Name] = append(refs[v.Name], models.ConfigReference{Kind: models.ReferenceSecret, Name: v.Secret.SecretName, Via: via})
+		case v.ConfigMap != nil:
+			refs[v.Name] = append(refs[v.Name], models.ConfigReference{Kind: models.ReferenceConfigMap, Name: v.ConfigMap.Name, Via: via})
+		case v.Projected != nil:
+			for _, p := range v.Projected.Sources {
+				if p.Secret != nil {
+					refs[v.Name] = append(refs[v.Name], models.ConfigReference{Kind: models.ReferenceSecret, Name: p.Secret.Name, Via: via})
+				}
+				if p.ConfigMap != nil {
+					refs[v.Name] = append(refs[v.Name], models.ConfigReference{Kind: models.ReferenceConfigMap, Name: p.ConfigMap.Name, Via: via})
+				}
+			}
+		}
+	}
+	return refs
+}
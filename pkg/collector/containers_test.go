@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{
+			args: []string{"--listen=:8080", "--db-password=hunter2", "-v"},
+			want: []string{"--listen=:8080", "--db-password=<redacted>", "-v"},
+		},
+		{
+			args: []string{"--token", "abc", "--name", "api"},
+			want: []string{"--token", "<redacted>", "--name", "api"},
+		},
+		{
+			// A sensitive flag without a value is kept as is
+			args: []string{"--insecure-skip-tls-verify", "--use-service-account-token", "--debug"},
+			want: []string{"--insecure-skip-tls-verify", "--use-service-account-token", "--debug"},
+		},
+		{
+			args: []string{"sh", "-c", "echo key"},
+			want: []string{"sh", "-c", "echo key"},
+		},
+	}
+	for _, tt := range tests {
+		if got := redactArgs(tt.args); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("redactArgs(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
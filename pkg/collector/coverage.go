@@ -0,0 +1,182 @@
+package collector
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"kubeRadar/pkg/models"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/pager"
+)
+
+// coverageRecorder accumulates the outcome of every List call made by the
+// concurrently running collection phases
+type coverageRecorder struct {
+	mu       sync.Mutex
+	coverage models.CoverageAssessment
+
+	namespacesOnce sync.Once
+	namespaces     []string
+	namespacesErr  error
+
+	// objects of an offline dump that failed to convert, by kind
+	dumpErrors map[string][]models.CollectionError
+}
+
+func newCoverageRecorder(dumpErrors []models.CollectionError) *coverageRecorder {
+	r := &coverageRecorder{
+		coverage: models.CoverageAssessment{
+			Kinds:  make([]models.KindCoverage, 0),
+			Errors: make([]models.CollectionError, 0),
+		},
+		dumpErrors: make(map[string][]models.CollectionError),
+	}
+	for _, e := range dumpErrors {
+		r.dumpErrors[e.Kind] = append(r.dumpErrors[e.Kind], e)
+	}
+	return r
+}
+
+// record stores the coverage of a kind along with the errors that caused any
+// gaps. Objects of the kind that failed to convert from an offline dump make
+// an otherwise complete kind partial, or failed when none was collected.
+func (r *coverageRecorder) record(kind string, collected, namespaces int, errs []models.CollectionError) {
+	status := models.CoverageComplete
+	switch {
+	case len(errs) > 0 && (namespaces == 0 || len(errs) >= namespaces):
+		status = models.CoverageFailed
+	case len(errs) > 0:
+		status = models.CoveragePartial
+	}
+	failedNamespaces := 0
+	for _, e := range errs {
+		if e.Namespace != "" {
+			failedNamespaces++
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if dumpErrs := r.dumpErrors[kind]; len(dumpErrs) > 0 {
+		if status == models.CoverageComplete {
+			status = models.CoveragePartial
+			if collected == 0 {
+				status = models.CoverageFailed
+			}
+		}
+		errs = append(append([]models.CollectionError{}, errs...), dumpErrs...)
+	}
+	r.coverage.Kinds = append(r.coverage.Kinds, models.KindCoverage{
+		Kind:             kind,
+		Status:           status,
+		Collected:        collected,
+		FailedNamespaces: failedNamespaces,
+	})
+	r.coverage.Errors = append(r.coverage.Errors, errs...)
+}
+
+// result returns the recorded coverage ordered by kind and namespace
+func (r *coverageRecorder) result() models.CoverageAssessment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sort.Slice(r.coverage.Kinds, func(i, j int) bool {
+		return r.coverage.Kinds[i].Kind < r.coverage.Kinds[j].Kind
+	})
+	sort.Slice(r.coverage.Errors, func(i, j int) bool {
+		a, b := r.coverage.Errors[i], r.coverage.Errors[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Namespace < b.Namespace
+	})
+	return r.coverage
+}
+
+func newCollectionError(kind, namespace string, err error) models.CollectionError {
+	reason := string(apierrors.ReasonForError(err))
+	if reason == "" {
+		reason = "Error"
+	}
+	return models.CollectionError{
+		Kind:      kind,
+		Namespace: namespace,
+		Reason:    reason,
+		Message:   err.Error(),
+	}
+}
+
+// namespaceNames lists the namespace names once per collection run
+func (c *Collector) namespaceNames(ctx context.Context) ([]string, error) {
+	r := c.coverage
+	r.namespacesOnce.Do(func() {
+		namespaces, err := listAll[corev1.Namespace](ctx, c, func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.CoreV1().Namespaces().List(ctx, opts)
+		})
+		if err != nil {
+			r.namespacesErr = err
+			return
+		}
+		for _, ns := range namespaces {
+			r.namespaces = append(r.namespaces, ns.Name)
+		}
+	})
+	return r.namespaces, r.namespacesErr
+}
+
+// listClusterScoped lists a cluster-scoped kind and records its coverage.
+// A failed list yields no items.
+func listClusterScoped[T any](ctx context.Context, c *Collector, kind string, list pager.ListPageFunc) []T {
+	items, err := listAll[T](ctx, c, list)
+	if err != nil {
+		c.coverage.record(kind, 0, 0, []models.CollectionError{newCollectionError(kind, "", err)})
+		return nil
+	}
+	c.coverage.record(kind, len(items), 0, nil)
+	return items
+}
+
+// listNamespaced lists a namespaced kind across all namespaces and records
+// its coverage. When the cluster-wide List is forbidden, for example because
+// the caller only holds RoleBindings, it falls back to one List per
+// namespace so that every readable namespace is still collected.
+func listNamespaced[T any](ctx context.Context, c *Collector, kind string, list func(namespace string) pager.ListPageFunc) []T {
+	items, namespaces, errs := listNamespacedItems[T](ctx, c, kind, list)
+	c.coverage.record(kind, len(items), namespaces, errs)
+	return items
+}
+
+// listNamespacedItems lists a namespaced kind like listNamespaced without
+// recording its coverage. It returns the number of namespaces listed one by
+// one, zero when the cluster-wide List was attempted only, along with the
+// errors that caused any gaps.
+func listNamespacedItems[T any](ctx context.Context, c *Collector, kind string, list func(namespace string) pager.ListPageFunc) ([]T, int, []models.CollectionError) {
+	items, err := listAll[T](ctx, c, list(metav1.NamespaceAll))
+	if err == nil {
+		return items, 0, nil
+	}
+	if !apierrors.IsForbidden(err) {
+		return nil, 0, []models.CollectionError{newCollectionError(kind, "", err)}
+	}
+
+	namespaces, nsErr := c.namespaceNames(ctx)
+	if nsErr != nil {
+		return nil, 0, []models.CollectionError{newCollectionError(kind, "", err)}
+	}
+
+	items = make([]T, 0)
+	errs := make([]models.CollectionError, 0)
+	for _, ns := range namespaces {
+		nsItems, err := listAll[T](ctx, c, list(ns))
+		if err != nil {
+			errs = append(errs, newCollectionError(kind, ns, err))
+			continue
+		}
+		items = append(items, nsItems...)
+	}
+	return items, len(namespaces), errs
+}
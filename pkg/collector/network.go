@@ -4,110 +4,118 @@ import (
 	"context"
 	"kubeRadar/pkg/models"
 
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/pager"
 )
 
 func (c *Collector) collectNetworkInfo(ctx context.Context) (models.NetworkAssessment, error) {
 	network := models.NetworkAssessment{}
 
-	namespaces, err := c.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return network, err
-	}
-
-	for _, ns := range namespaces.Items {
-		// Collect Services
-		services, err := c.client.CoreV1().Services(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue
+	// Collect Services
+	services := listNamespaced[corev1.Service](ctx, c, "Services", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.CoreV1().Services(namespace).List(ctx, opts)
 		}
-
-		for _, svc := range services.Items {
-			ports := make([]models.ServicePort, 0)
-			for _, port := range svc.Spec.Ports {
-				ports = append(ports, models.ServicePort{
-					Port:       port.Port,
-					Protocol:   string(port.Protocol),
-					TargetPort: port.TargetPort.IntVal,
-				})
-			}
-
-			network.Services = append(network.Services, models.ServiceInfo{
-				Name:        svc.Name,
-				Namespace:   svc.Namespace,
-				Labels:      svc.Labels,
-				CreatedAt:   svc.CreationTimestamp.String(),
-				Type:        string(svc.Spec.Type),
-				ClusterIP:   svc.Spec.ClusterIP,
-				ExternalIPs: svc.Spec.ExternalIPs,
-				Ports:       ports,
-				Size:        0,
+	})
+	for _, svc := range services {
+		ports := make([]models.ServicePort, 0)
+		for _, port := range svc.Spec.Ports {
+			ports = append(ports, models.ServicePort{
+				Port:       port.Port,
+				Protocol:   string(port.Protocol),
+				TargetPort: port.TargetPort.IntVal,
 			})
 		}
 
-		// Collect NetworkPolicies
-		netpols, err := c.client.NetworkingV1().NetworkPolicies(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue
-		}
-
-		for _, netpol := range netpols.Items {
-			policyTypes := make([]string, 0)
-			for _, ptype := range netpol.Spec.PolicyTypes {
-				policyTypes = append(policyTypes, string(ptype))
-			}
+		network.Services = append(network.Services, models.ServiceInfo{
+			Name:        svc.Name,
+			Namespace:   svc.Namespace,
+			Labels:      svc.Labels,
+			CreatedAt:   creationTime(svc.ObjectMeta),
+			Type:        string(svc.Spec.Type),
+			ClusterIP:   svc.Spec.ClusterIP,
+			ExternalIPs: svc.Spec.ExternalIPs,
+			Selector:    svc.Spec.Selector,
+			Ports:       ports,
+			Size:        0,
+		})
+	}
 
-			network.NetworkPolicies = append(network.NetworkPolicies, models.NetworkPolicyInfo{
-				Name:        netpol.Name,
-				Namespace:   netpol.Namespace,
-				Labels:      netpol.Labels,
-				CreatedAt:   netpol.CreationTimestamp.String(),
-				PodSelector: netpol.Spec.PodSelector.String(),
-				PolicyTypes: policyTypes,
-			})
+	// Collect NetworkPolicies
+	netpols := listNamespaced[networkingv1.NetworkPolicy](ctx, c, "NetworkPolicies", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
 		}
-
-		// Collect Ingresses
-		ingresses, err := c.client.NetworkingV1().Ingresses(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue
+	})
+	for _, netpol := range netpols {
+		policyTypes := make([]string, 0)
+		for _, ptype := range netpol.Spec.PolicyTypes {
+			policyTypes = append(policyTypes, string(ptype))
 		}
 
-		for _, ing := range ingresses.Items {
-			ingressRules := make([]models.IngressRule, 0)
-			for _, rule := range ing.Spec.Rules {
-				if rule.Host != "" {
-					paths := make([]models.IngressPath, 0)
-					if rule.HTTP != nil {
-						for _, path := range rule.HTTP.Paths {
-							paths = append(paths, models.IngressPath{
-								Path:        path.Path,
-								ServiceName: path.Backend.Service.Name,
-								ServicePort: path.Backend.Service.Port.Number,
-							})
+		network.NetworkPolicies = append(network.NetworkPolicies, models.NetworkPolicyInfo{
+			Name:        netpol.Name,
+			Namespace:   netpol.Namespace,
+			Labels:      netpol.Labels,
+			CreatedAt:   creationTime(netpol.ObjectMeta),
+			PodSelector: netpol.Spec.PodSelector.String(),
+			PolicyTypes: policyTypes,
+		})
+	}
+
+	// Collect Ingresses
+	ingresses := listNamespaced[networkingv1.Ingress](ctx, c, "Ingresses", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+		}
+	})
+	for _, ing := range ingresses {
+		ingressRules := make([]models.IngressRule, 0)
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host != "" {
+				paths := make([]models.IngressPath, 0)
+				if rule.HTTP != nil {
+					for _, path := range rule.HTTP.Paths {
+						// Resource backends have no service to record
+						if path.Backend.Service == nil {
+							continue
 						}
+						paths = append(paths, models.IngressPath{
+							Path:        path.Path,
+							ServiceName: path.Backend.Service.Name,
+							ServicePort: path.Backend.Service.Port.Number,
+						})
 					}
-					ingressRules = append(ingressRules, models.IngressRule{
-						Host:  rule.Host,
-						Paths: paths,
-					})
 				}
+				ingressRules = append(ingressRules, models.IngressRule{
+					Host:  rule.Host,
+					Paths: paths,
+				})
 			}
+		}
 
-			tlsHosts := make([]string, 0)
-			for _, tls := range ing.Spec.TLS {
-				tlsHosts = append(tlsHosts, tls.Hosts...)
+		tlsHosts := make([]string, 0)
+		tlsSecrets := make([]string, 0)
+		for _, tls := range ing.Spec.TLS {
+			tlsHosts = append(tlsHosts, tls.Hosts...)
+			// Without a secret name the controller serves its default certificate
+			if tls.SecretName != "" {
+				tlsSecrets = append(tlsSecrets, tls.SecretName)
 			}
-
-			network.Ingresses = append(network.Ingresses, models.IngressInfo{
-				Name:      ing.Name,
-				Namespace: ing.Namespace,
-				Labels:    ing.Labels,
-				CreatedAt: ing.CreationTimestamp.String(),
-				Rules:     ingressRules,
-				TLS:       tlsHosts,
-			})
 		}
+
+		network.Ingresses = append(network.Ingresses, models.IngressInfo{
+			Name:       ing.Name,
+			Namespace:  ing.Namespace,
+			Labels:     ing.Labels,
+			CreatedAt:  creationTime(ing.ObjectMeta),
+			Rules:      ingressRules,
+			TLS:        tlsHosts,
+			TLSSecrets: tlsSecrets,
+		})
 	}
 
 	return network, nil
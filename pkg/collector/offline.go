@@ -0,0 +1,245 @@
+package collector
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"kubeRadar/pkg/models"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// NewOfflineCollector creates a collector backed by kubectl or must-gather
+// dumps instead of a live cluster. path may be a single YAML/JSON file, a
+// directory of them, or a .tar/.tar.gz/.tgz archive.
+func NewOfflineCollector(path string, opts Options) (*Collector, error) {
+	objects, failures, err := loadDump(path)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset := fake.NewClientset()
+	serverVersion := ""
+	for _, obj := range objects {
+		if err := clientset.Tracker().Add(obj); err != nil && !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to load %s: %v", obj.GetObjectKind().GroupVersionKind().Kind, err)
+		}
+		// Dumps carry no server version. The kubelet version of the first
+		// node is shown instead, labeled as such.
+		if node, ok := obj.(*corev1.Node); ok && serverVersion == "" && node.Status.NodeInfo.KubeletVersion != "" {
+			serverVersion = node.Status.NodeInfo.KubeletVersion + " (kubelet)"
+		}
+	}
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: serverVersion}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	c := NewCollectorForClients(Clients{Kube: clientset}, &rest.Config{Host: "file://" + abs}, opts)
+	c.dumpErrors = failures
+	return c, nil
+}
+
+// loadDump reads every Kubernetes object found under path. Objects that
+// cannot be converted to their client-go type are returned as collection
+// errors of their kind.
+func loadDump(path string) ([]runtime.Object, []models.CollectionError, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	objects := make([]runtime.Object, 0)
+	failures := make([]models.CollectionError, 0)
+	add := func(name string, content []byte) {
+		objs, errs, err := decodeObjects(content)
+		for _, e := range errs {
+			fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: skipping %s in %s: %s\n", e.Kind, name, e.Message)
+		}
+		failures = append(failures, errs...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: skipping %s: %v\n", name, err)
+			return
+		}
+		objects = append(objects, objs...)
+	}
+
+	switch {
+	case info.IsDir():
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !isManifest(p) {
+				return err
+			}
+			content, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			add(p, content)
+			return nil
+		})
+	case isArchive(path):
+		err = walkArchive(path, add)
+	default:
+		var content []byte
+		content, err = os.ReadFile(path)
+		if err == nil {
+			add(path, content)
+		}
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read dump %s: %v", path, err)
+	}
+	if len(objects) == 0 && len(failures) == 0 {
+		return nil, nil, fmt.Errorf("no Kubernetes objects found in %s", path)
+	}
+	return objects, failures, nil
+}
+
+// walkArchive calls add for every manifest in a tar or gzipped tar archive
+func walkArchive(path string, add func(name string, content []byte)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if !strings.HasSuffix(path, ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg || !isManifest(hdr.Name) {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		add(hdr.Name, content)
+	}
+}
+
+// decodeObjects converts a YAML or JSON document stream into typed objects,
+// expanding List kinds and skipping kinds the collector does not understand.
+// Objects of known kinds that fail to convert are returned as errors.
+func decodeObjects(content []byte) ([]runtime.Object, []models.CollectionError, error) {
+	objects := make([]runtime.Object, 0)
+	errs := make([]models.CollectionError, 0)
+	add := func(u *unstructured.Unstructured) {
+		obj, err := toTyped(u)
+		if err != nil {
+			errs = append(errs, newCollectionError(coverageKind(u.GetKind()), u.GetNamespace(), err))
+			return
+		}
+		if obj != nil {
+			objects = append(objects, obj)
+		}
+	}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		doc := make(map[string]interface{})
+		if err := decoder.Decode(&doc); err != nil {
+			if err == io.EOF {
+				return objects, errs, nil
+			}
+			return objects, errs, err
+		}
+		if len(doc) == 0 {
+			continue
+		}
+
+		u := &unstructured.Unstructured{Object: doc}
+		if !u.IsList() {
+			add(u)
+			continue
+		}
+
+		list, err := u.ToList()
+		if err != nil {
+			return objects, errs, err
+		}
+		// API list responses omit apiVersion/kind on their items
+		itemKind := strings.TrimSuffix(u.GetKind(), "List")
+		for i := range list.Items {
+			item := &list.Items[i]
+			if item.GetKind() == "" && itemKind != "" {
+				item.SetAPIVersion(u.GetAPIVersion())
+				item.SetKind(itemKind)
+			}
+			add(item)
+		}
+	}
+}
+
+// toTyped converts an unstructured object into its client-go type. It
+// returns nil without an error for kinds that are not part of the built-in
+// scheme.
+func toTyped(u *unstructured.Unstructured) (runtime.Object, error) {
+	gvk := u.GroupVersionKind()
+	if gvk.Kind == "" {
+		return nil, nil
+	}
+	obj, err := scheme.Scheme.New(gvk)
+	if err != nil {
+		return nil, nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s: %v", gvk.Kind, u.GetName(), err)
+	}
+	return obj, nil
+}
+
+// coverageKind returns the plural kind name coverage is recorded under
+func coverageKind(kind string) string {
+	switch {
+	case strings.HasSuffix(kind, "y"):
+		return strings.TrimSuffix(kind, "y") + "ies"
+	case strings.HasSuffix(kind, "s"):
+		return kind + "es"
+	}
+	return kind + "s"
+}
+
+func isManifest(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func isArchive(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, ".tar")
+}
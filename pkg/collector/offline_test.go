@@ -0,0 +1,138 @@
+package collector
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"kubeRadar/pkg/fixture"
+	"kubeRadar/pkg/models"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// writeDump writes the fixture cluster as a multi-document YAML dump
+func writeDump(t *testing.T, path string) {
+	t.Helper()
+	var dump []byte
+	for _, obj := range fixture.Objects() {
+		gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+		if err != nil {
+			t.Fatalf("unknown fixture kind %T: %v", obj, err)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+		doc, err := yaml.Marshal(obj)
+		if err != nil {
+			t.Fatalf("failed to encode %T: %v", obj, err)
+		}
+		dump = append(dump, "---\n"...)
+		dump = append(dump, doc...)
+	}
+	if err := os.WriteFile(path, dump, 0o600); err != nil {
+		t.Fatalf("failed to write dump: %v", err)
+	}
+}
+
+func TestOfflineCollectorMatchesLive(t *testing.T) {
+	live, err := newFixtureCollector(fixture.Clientset()).CollectAll()
+	if err != nil {
+		t.Fatalf("live CollectAll failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "dump.yaml")
+	writeDump(t, path)
+	c, err := NewOfflineCollector(path, Options{Context: "fixture", Workers: 2, InspectSecrets: true})
+	if err != nil {
+		t.Fatalf("NewOfflineCollector failed: %v", err)
+	}
+	c.now = func() time.Time { return fixture.CollectedAt }
+	offline, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("offline CollectAll failed: %v", err)
+	}
+
+	if want := fixture.ServerVersion + " (kubelet)"; offline.ClusterInfo.Version != want {
+		t.Errorf("got offline version %q, want the labeled kubelet version %q", offline.ClusterInfo.Version, want)
+	}
+	// Only the API server and version differ between a dump and a live cluster
+	offline.ClusterInfo.APIServer = live.ClusterInfo.APIServer
+	offline.ClusterInfo.Version = live.ClusterInfo.Version
+	want, _ := json.Marshal(live)
+	got, _ := json.Marshal(offline)
+	if string(got) != string(want) {
+		t.Errorf("offline collection differs from live collection:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestOfflineCollectorRecordsConversionFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dump.yaml")
+	dump := `apiVersion: v1
+kind: Namespace
+metadata:
+  name: payments
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: payments
+spec:
+  replicas: 2
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+  namespace: payments
+spec:
+  replicas: three
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+  namespace: payments
+spec:
+  ports: 80
+`
+	if err := os.WriteFile(path, []byte(dump), 0o600); err != nil {
+		t.Fatalf("failed to write dump: %v", err)
+	}
+	c, err := NewOfflineCollector(path, Options{Workers: 1})
+	if err != nil {
+		t.Fatalf("NewOfflineCollector failed: %v", err)
+	}
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+
+	// Without nodes the dump records no version at all
+	if data.ClusterInfo.Version != "" {
+		t.Errorf("got version %q for a dump without nodes, want none", data.ClusterInfo.Version)
+	}
+	status := make(map[string]string)
+	for _, k := range data.Coverage.Kinds {
+		status[k.Kind] = k.Status
+	}
+	for kind, want := range map[string]string{
+		"Deployments": models.CoveragePartial,
+		"Services":    models.CoverageFailed,
+		"Namespaces":  models.CoverageComplete,
+	} {
+		if status[kind] != want {
+			t.Errorf("%s coverage is %q, want %q", kind, status[kind], want)
+		}
+	}
+	failed := make(map[string]bool)
+	for _, e := range data.Coverage.Errors {
+		failed[e.Kind] = e.Namespace == "payments" && strings.Contains(e.Message, "failed to convert")
+	}
+	if !failed["Deployments"] || !failed["Services"] || len(data.Coverage.Errors) != 2 {
+		t.Errorf("got collection errors %+v, want one per object that failed to convert", data.Coverage.Errors)
+	}
+}
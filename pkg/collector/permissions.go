@@ -0,0 +1,58 @@
+package collector
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// requiredRules lists every resource the collector reads. Keep it in sync
+// with the List calls made by the collect* functions.
+var requiredRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"nodes", "namespaces", "pods", "replicationcontrollers", "services", "secrets", "configmaps", "serviceaccounts"},
+		Verbs:     []string{"list"},
+	},
+	{
+		APIGroups: []string{"apps"},
+		Resources: []string{"deployments", "statefulsets", "daemonsets", "replicasets"},
+		Verbs:     []string{"list"},
+	},
+	{
+		APIGroups: []string{"batch"},
+		Resources: []string{"jobs", "cronjobs"},
+		Verbs:     []string{"list"},
+	},
+	{
+		APIGroups: []string{"networking.k8s.io"},
+		Resources: []string{"networkpolicies", "ingresses"},
+		Verbs:     []string{"list"},
+	},
+	{
+		APIGroups: []string{"rbac.authorization.k8s.io"},
+		Resources: []string{"roles", "rolebindings", "clusterroles", "clusterrolebindings"},
+		Verbs:     []string{"list"},
+	},
+}
+
+// ReaderClusterRole returns the minimal read-only ClusterRole needed to run a
+// full collection
+func ReaderClusterRole(name string) *rbacv1.ClusterRole {
+	rules := make([]rbacv1.PolicyRule, len(requiredRules))
+	for i, rule := range requiredRules {
+		rules[i] = *rule.DeepCopy()
+	}
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "kuberadar",
+			},
+		},
+		Rules: rules,
+	}
+}
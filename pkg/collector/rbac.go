@@ -4,144 +4,152 @@ import (
 	"context"
 	"kubeRadar/pkg/models"
 
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/pager"
 )
 
 func (c *Collector) collectRBACInfo(ctx context.Context) (models.RBACAssessment, error) {
 	rbac := models.RBACAssessment{}
 
 	// Collect ClusterRoles
-	clusterRoles, err := c.client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return rbac, err
-	}
-
-	for _, cr := range clusterRoles.Items {
-		rules := make([]models.PolicyRule, 0)
-		for _, rule := range cr.Rules {
-			rules = append(rules, models.PolicyRule{
-				APIGroups:     rule.APIGroups,
-				Resources:     rule.Resources,
-				ResourceNames: rule.ResourceNames,
-				Verbs:         rule.Verbs,
-			})
-		}
+	clusterRoles := listClusterScoped[rbacv1.ClusterRole](ctx, c, "ClusterRoles", func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return c.client.RbacV1().ClusterRoles().List(ctx, opts)
+	})
 
+	for _, cr := range clusterRoles {
 		rbac.ClusterRoles = append(rbac.ClusterRoles, models.RoleInfo{
-			Name:        cr.Name,
-			Namespace:   "", // ClusterRoles are cluster-scoped
-			ClusterRole: true,
-			Rules:       rules,
-			CreatedAt:   cr.CreationTimestamp.String(),
+			Name:            cr.Name,
+			Namespace:       "", // ClusterRoles are cluster-scoped
+			ClusterRole:     true,
+			Labels:          cr.Labels,
+			Rules:           convertRules(cr.Rules),
+			AggregationRule: convertAggregationRule(cr.AggregationRule),
+			CreatedAt:       creationTime(cr.ObjectMeta),
 		})
 	}
 
 	// Collect ClusterRoleBindings
-	clusterRoleBindings, err := c.client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return rbac, err
-	}
-
-	for _, crb := range clusterRoleBindings.Items {
-		subjects := make([]models.Subject, 0)
-		for _, subject := range crb.Subjects {
-			subjects = append(subjects, models.Subject{
-				Kind:      subject.Kind,
-				Name:      subject.Name,
-				Namespace: subject.Namespace,
-			})
-		}
+	clusterRoleBindings := listClusterScoped[rbacv1.ClusterRoleBinding](ctx, c, "ClusterRoleBindings", func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		return c.client.RbacV1().ClusterRoleBindings().List(ctx, opts)
+	})
 
+	for _, crb := range clusterRoleBindings {
 		rbac.ClusterRoleBindings = append(rbac.ClusterRoleBindings, models.BindingInfo{
 			Name:      crb.Name,
 			Namespace: "", // ClusterRoleBindings are cluster-scoped
-			RoleRef:   crb.RoleRef.Name,
-			Subjects:  subjects,
-			CreatedAt: crb.CreationTimestamp.String(),
+			RoleRef:   convertRoleRef(crb.RoleRef),
+			Subjects:  convertSubjects(crb.Subjects),
+			CreatedAt: creationTime(crb.ObjectMeta),
 		})
 	}
 
-	// Collect Roles and RoleBindings from all namespaces
-	namespaces, err := c.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return rbac, err
+	// Collect Roles from all namespaces
+	roles := listNamespaced[rbacv1.Role](ctx, c, "Roles", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.RbacV1().Roles(namespace).List(ctx, opts)
+		}
+	})
+	for _, role := range roles {
+		rbac.Roles = append(rbac.Roles, models.RoleInfo{
+			Name:        role.Name,
+			Namespace:   role.Namespace,
+			ClusterRole: false,
+			Labels:      role.Labels,
+			Rules:       convertRules(role.Rules),
+			CreatedAt:   creationTime(role.ObjectMeta),
+		})
 	}
 
-	for _, ns := range namespaces.Items {
-		// Collect Roles
-		roles, err := c.client.RbacV1().Roles(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue
+	// Collect RoleBindings from all namespaces
+	roleBindings := listNamespaced[rbacv1.RoleBinding](ctx, c, "RoleBindings", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.RbacV1().RoleBindings(namespace).List(ctx, opts)
 		}
+	})
+	for _, rb := range roleBindings {
+		rbac.RoleBindings = append(rbac.RoleBindings, models.BindingInfo{
+			Name:      rb.Name,
+			Namespace: rb.Namespace,
+			RoleRef:   convertRoleRef(rb.RoleRef),
+			Subjects:  convertSubjects(rb.Subjects),
+			CreatedAt: creationTime(rb.ObjectMeta),
+		})
+	}
 
-		for _, role := range roles.Items {
-			rules := make([]models.PolicyRule, 0)
-			for _, rule := range role.Rules {
-				rules = append(rules, models.PolicyRule{
-					APIGroups:     rule.APIGroups,
-					Resources:     rule.Resources,
-					ResourceNames: rule.ResourceNames,
-					Verbs:         rule.Verbs,
-				})
-			}
-
-			rbac.Roles = append(rbac.Roles, models.RoleInfo{
-				Name:        role.Name,
-				Namespace:   role.Namespace,
-				ClusterRole: false,
-				Rules:       rules,
-				CreatedAt:   role.CreationTimestamp.String(),
-			})
+	// Collect ServiceAccounts from all namespaces
+	serviceAccounts := listNamespaced[corev1.ServiceAccount](ctx, c, "ServiceAccounts", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
 		}
-
-		// Collect RoleBindings
-		roleBindings, err := c.client.RbacV1().RoleBindings(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue
+	})
+	for _, sa := range serviceAccounts {
+		secrets := make([]string, 0)
+		for _, s := range sa.Secrets {
+			secrets = append(secrets, s.Name)
 		}
+		imagePullSecrets := make([]string, 0)
+		for _, ips := range sa.ImagePullSecrets {
+			imagePullSecrets = append(imagePullSecrets, ips.Name)
+		}
+		rbac.ServiceAccounts = append(rbac.ServiceAccounts, models.ServiceAccountInfo{
+			Name:                         sa.Name,
+			Namespace:                    sa.Namespace,
+			Labels:                       sa.Labels,
+			CreatedAt:                    creationTime(sa.ObjectMeta),
+			Secrets:                      secrets,
+			ImagePullSecrets:             imagePullSecrets,
+			AutomountServiceAccountToken: sa.AutomountServiceAccountToken,
+		})
+	}
+	return rbac, nil
+}
 
-		for _, rb := range roleBindings.Items {
-			subjects := make([]models.Subject, 0)
-			for _, subject := range rb.Subjects {
-				subjects = append(subjects, models.Subject{
-					Kind:      subject.Kind,
-					Name:      subject.Name,
-					Namespace: subject.Namespace,
-				})
-			}
+func convertRules(policyRules []rbacv1.PolicyRule) []models.PolicyRule {
+	rules := make([]models.PolicyRule, 0)
+	for _, rule := range policyRules {
+		rules = append(rules, models.PolicyRule{
+			APIGroups:       rule.APIGroups,
+			Resources:       rule.Resources,
+			ResourceNames:   rule.ResourceNames,
+			NonResourceURLs: rule.NonResourceURLs,
+			Verbs:           rule.Verbs,
+		})
+	}
+	return rules
+}
 
-			rbac.RoleBindings = append(rbac.RoleBindings, models.BindingInfo{
-				Name:      rb.Name,
-				Namespace: rb.Namespace,
-				RoleRef:   rb.RoleRef.Name,
-				Subjects:  subjects,
-				CreatedAt: rb.CreationTimestamp.String(),
-			})
-		}
+// convertAggregationRule formats the ClusterRole selectors of an aggregation
+// rule in label selector syntax, e.g. rbac.example.com/aggregate-to-admin=true
+func convertAggregationRule(rule *rbacv1.AggregationRule) []string {
+	if rule == nil {
+		return nil
+	}
+	selectors := make([]string, 0, len(rule.ClusterRoleSelectors))
+	for i := range rule.ClusterRoleSelectors {
+		selectors = append(selectors, metav1.FormatLabelSelector(&rule.ClusterRoleSelectors[i]))
+	}
+	return selectors
+}
 
-		// Collect ServiceAccounts from all namespaces
-		serviceAccounts, err := c.client.CoreV1().ServiceAccounts(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue
-		}
-		for _, sa := range serviceAccounts.Items {
-			secrets := make([]string, 0)
-			for _, s := range sa.Secrets {
-				secrets = append(secrets, s.Name)
-			}
-			imagePullSecrets := make([]string, 0)
-			for _, ips := range sa.ImagePullSecrets {
-				imagePullSecrets = append(imagePullSecrets, ips.Name)
-			}
-			rbac.ServiceAccounts = append(rbac.ServiceAccounts, models.ServiceAccountInfo{
-				Name:             sa.Name,
-				Namespace:        sa.Namespace,
-				Labels:           sa.Labels,
-				CreatedAt:        sa.CreationTimestamp.String(),
-				Secrets:          secrets,
-				ImagePullSecrets: imagePullSecrets,
-			})
-		}
+func convertRoleRef(ref rbacv1.RoleRef) models.RoleRef {
+	return models.RoleRef{
+		APIGroup: ref.APIGroup,
+		Kind:     ref.Kind,
+		Name:     ref.Name,
 	}
-	return rbac, nil
+}
+
+func convertSubjects(bindingSubjects []rbacv1.Subject) []models.Subject {
+	subjects := make([]models.Subject, 0)
+	for _, subject := range bindingSubjects {
+		subjects = append(subjects, models.Subject{
+			Kind:      subject.Kind,
+			Name:      subject.Name,
+			Namespace: subject.Namespace,
+		})
+	}
+	return subjects
 }
@@ -2,37 +2,138 @@ package collector
 
 import (
 	"context"
+	"sort"
+	"strings"
+
 	"kubeRadar/pkg/models"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/pager"
 )
 
+// collectSecretInfo lists Secrets without their payloads through the metadata
+// client. Secret data is only fetched when opts.InspectSecrets is set, or
+// when no metadata client is available, and is dropped right after conversion.
 func (c *Collector) collectSecretInfo(ctx context.Context) (models.SecretAssessment, error) {
-	secretAssessment := models.SecretAssessment{}
+	secretAssessment := models.SecretAssessment{Inspected: c.opts.InspectSecrets}
 
-	namespaces, err := c.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return secretAssessment, err
+	if c.metadata != nil && !c.opts.InspectSecrets {
+		secretAssessment.Secrets = c.collectSecretMetadata(ctx)
+		return secretAssessment, nil
 	}
 
-	for _, ns := range namespaces.Items {
-		secrets, err := c.client.CoreV1().Secrets(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue
+	secrets := listNamespaced[corev1.Secret](ctx, c, "Secrets", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.CoreV1().Secrets(namespace).List(ctx, opts)
+		}
+	})
+	for _, secret := range secrets {
+		info := models.SecretInfo{
+			CommonInfo: secretCommonInfo(secret.ObjectMeta),
+			Type:       string(secret.Type),
+		}
+		if c.opts.InspectSecrets {
+			info.Keys = secretKeys(secret)
+			if secret.Type == corev1.SecretTypeTLS {
+				certs, err := parseCertificates(secret.Data[corev1.TLSCertKey])
+				info.Certificates = certs
+				if err != nil {
+					info.CertificateError = err.Error()
+				}
+			}
 		}
+		secretAssessment.Secrets = append(secretAssessment.Secrets, info)
+	}
+
+	return secretAssessment, nil
+}
+
+func secretCommonInfo(meta metav1.ObjectMeta) models.CommonInfo {
+	return models.CommonInfo{
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Labels:    meta.Labels,
+		CreatedAt: creationTime(meta),
+	}
+}
 
-		for _, secret := range secrets.Items {
-			secretAssessment.Secrets = append(secretAssessment.Secrets, models.SecretInfo{
-				CommonInfo: models.CommonInfo{
-					Name:      secret.Name,
-					Namespace: secret.Namespace,
-					Labels:    secret.Labels,
-					CreatedAt: secret.CreationTimestamp.String(),
-				},
-				Type: string(secret.Type),
+// secretTypes are the Secret types Kubernetes and Helm manage. Without
+// payloads, Secrets are listed once per type so that their type is known.
+var secretTypes = []corev1.SecretType{
+	corev1.SecretTypeOpaque,
+	corev1.SecretTypeServiceAccountToken,
+	corev1.SecretTypeDockercfg,
+	corev1.SecretTypeDockerConfigJson,
+	corev1.SecretTypeBasicAuth,
+	corev1.SecretTypeSSHAuth,
+	corev1.SecretTypeTLS,
+	corev1.SecretTypeBootstrapToken,
+	"helm.sh/release.v1",
+}
+
+// collectSecretMetadata lists the metadata of Secrets with one field selected
+// List per type in secretTypes, followed by a List of every other type. Those
+// Secrets keep an empty Type, as their type can only be read from the
+// payload. Coverage is recorded once for all Lists.
+func (c *Collector) collectSecretMetadata(ctx context.Context) []models.SecretInfo {
+	resource := c.metadata.Resource(corev1.SchemeGroupVersion.WithResource("secrets"))
+	selectors := make([]string, 0, len(secretTypes)+1)
+	others := make([]string, 0, len(secretTypes))
+	for _, t := range secretTypes {
+		selectors = append(selectors, "type="+string(t))
+		others = append(others, "type!="+string(t))
+	}
+	selectors = append(selectors, strings.Join(others, ","))
+
+	secrets := make([]models.SecretInfo, 0)
+	namespaces := 0
+	errs := make([]models.CollectionError, 0)
+	failed := make(map[string]bool)
+	for i, selector := range selectors {
+		items, n, listErrs := listNamespacedItems[metav1.PartialObjectMetadata](ctx, c, "Secrets", func(namespace string) pager.ListPageFunc {
+			return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+				opts.FieldSelector = selector
+				return resource.Namespace(namespace).List(ctx, opts)
+			}
+		})
+		typ := ""
+		if i < len(secretTypes) {
+			typ = string(secretTypes[i])
+		}
+		for _, secret := range items {
+			secrets = append(secrets, models.SecretInfo{
+				CommonInfo: secretCommonInfo(secret.ObjectMeta),
+				Type:       typ,
 			})
 		}
+		namespaces = max(namespaces, n)
+		// A namespace that cannot be read fails every List, report it once
+		for _, e := range listErrs {
+			if !failed[e.Namespace] {
+				failed[e.Namespace] = true
+				errs = append(errs, e)
+			}
+		}
 	}
+	c.coverage.record("Secrets", len(secrets), namespaces, errs)
+	// Keep the order of a single List
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Namespace != secrets[j].Namespace {
+			return secrets[i].Namespace < secrets[j].Namespace
+		}
+		return secrets[i].Name < secrets[j].Name
+	})
+	return secrets
+}
 
-	return secretAssessment, nil
+// secretKeys returns the sorted data keys of a Secret, never its values
+func secretKeys(secret corev1.Secret) []string {
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
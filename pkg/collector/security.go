@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// seccompProfile formats a seccomp profile as its type, followed by the
+// profile path for Localhost profiles
+func seccompProfile(p *corev1.SeccompProfile) string {
+	if p == nil {
+		return ""
+	}
+	if p.Type == corev1.SeccompProfileTypeLocalhost && p.LocalhostProfile != nil {
+		return fmt.Sprintf("%s/%s", p.Type, *p.LocalhostProfile)
+	}
+	return string(p.Type)
+}
+
+// appArmorProfile formats an AppArmor profile like seccompProfile
+func appArmorProfile(p *corev1.AppArmorProfile) string {
+	if p == nil {
+		return ""
+	}
+	if p.Type == corev1.AppArmorProfileTypeLocalhost && p.LocalhostProfile != nil {
+		return fmt.Sprintf("%s/%s", p.Type, *p.LocalhostProfile)
+	}
+	return string(p.Type)
+}
+
+// appArmorAnnotation converts a legacy AppArmor annotation value, e.g.
+// runtime/default or localhost/<profile>, to the appArmorProfile format
+func appArmorAnnotation(value string) string {
+	switch {
+	case value == "":
+		return ""
+	case value == corev1.DeprecatedAppArmorBetaProfileRuntimeDefault:
+		return string(corev1.AppArmorProfileTypeRuntimeDefault)
+	case value == corev1.DeprecatedAppArmorBetaProfileNameUnconfined:
+		return string(corev1.AppArmorProfileTypeUnconfined)
+	case strings.HasPrefix(value, corev1.DeprecatedAppArmorBetaProfileNamePrefix):
+		return fmt.Sprintf("%s/%s", corev1.AppArmorProfileTypeLocalhost, strings.TrimPrefix(value, corev1.DeprecatedAppArmorBetaProfileNamePrefix))
+	}
+	return value
+}
+
+// seLinuxOptions formats the set SELinux labels as key=value pairs
+func seLinuxOptions(o *corev1.SELinuxOptions) string {
+	if o == nil {
+		return ""
+	}
+	parts := make([]string, 0, 4)
+	for _, kv := range [][2]string{{"user", o.User}, {"role", o.Role}, {"type", o.Type}, {"level", o.Level}} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// windowsOptions formats the set Windows options as key=value pairs
+func windowsOptions(o *corev1.WindowsSecurityContextOptions) string {
+	if o == nil {
+		return ""
+	}
+	parts := make([]string, 0, 3)
+	if o.GMSACredentialSpecName != nil {
+		parts = append(parts, "gmsaCredentialSpecName="+*o.GMSACredentialSpecName)
+	}
+	if o.RunAsUserName != nil {
+		parts = append(parts, "runAsUserName="+*o.RunAsUserName)
+	}
+	if o.HostProcess != nil {
+		parts = append(parts, fmt.Sprintf("hostProcess=%v", *o.HostProcess))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,52 @@
+package collector
+
+import (
+	"sort"
+
+	"kubeRadar/pkg/models"
+)
+
+// sortAssessment orders every collected list by namespace and name so that
+// reports are stable regardless of the order the source returned objects in
+func sortAssessment(data *models.AssessmentData) {
+	sort.Slice(data.ClusterInfo.Nodes, func(i, j int) bool {
+		return data.ClusterInfo.Nodes[i].Name < data.ClusterInfo.Nodes[j].Name
+	})
+	sort.Slice(data.ClusterInfo.Namespaces, func(i, j int) bool {
+		return data.ClusterInfo.Namespaces[i].Name < data.ClusterInfo.Namespaces[j].Name
+	})
+
+	sortByKey(data.RBAC.ClusterRoles, func(r models.RoleInfo) (string, string) { return r.Namespace, r.Name })
+	sortByKey(data.RBAC.ClusterRoleBindings, func(b models.BindingInfo) (string, string) { return b.Namespace, b.Name })
+	sortByKey(data.RBAC.Roles, func(r models.RoleInfo) (string, string) { return r.Namespace, r.Name })
+	sortByKey(data.RBAC.RoleBindings, func(b models.BindingInfo) (string, string) { return b.Namespace, b.Name })
+	sortByKey(data.RBAC.ServiceAccounts, func(sa models.ServiceAccountInfo) (string, string) { return sa.Namespace, sa.Name })
+
+	sortByKey(data.Workloads.Pods, func(p models.PodInfo) (string, string) { return p.Namespace, p.Name })
+	sortByKey(data.Workloads.Deployments, func(d models.DeploymentInfo) (string, string) { return d.Namespace, d.Name })
+	sortByKey(data.Workloads.StatefulSets, func(s models.StatefulSetInfo) (string, string) { return s.Namespace, s.Name })
+	sortByKey(data.Workloads.DaemonSets, func(d models.DaemonSetInfo) (string, string) { return d.Namespace, d.Name })
+	sortByKey(data.Workloads.Jobs, func(j models.JobInfo) (string, string) { return j.Namespace, j.Name })
+	sortByKey(data.Workloads.CronJobs, func(c models.CronJobInfo) (string, string) { return c.Namespace, c.Name })
+	sortByKey(data.Workloads.ReplicaSets, func(r models.ReplicaSetInfo) (string, string) { return r.Namespace, r.Name })
+	sortByKey(data.Workloads.ReplicationControllers, func(r models.ReplicationControllerInfo) (string, string) { return r.Namespace, r.Name })
+
+	sortByKey(data.Network.Services, func(s models.ServiceInfo) (string, string) { return s.Namespace, s.Name })
+	sortByKey(data.Network.NetworkPolicies, func(n models.NetworkPolicyInfo) (string, string) { return n.Namespace, n.Name })
+	sortByKey(data.Network.Ingresses, func(i models.IngressInfo) (string, string) { return i.Namespace, i.Name })
+
+	sortByKey(data.Secrets.Secrets, func(s models.SecretInfo) (string, string) { return s.Namespace, s.Name })
+	sortByKey(data.ConfigMaps.ConfigMaps, func(c models.ConfigMapInfo) (string, string) { return c.Namespace, c.Name })
+}
+
+// sortByKey sorts items by the namespace and name returned by key
+func sortByKey[T any](items []T, key func(T) (string, string)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		nsI, nameI := key(items[i])
+		nsJ, nameJ := key(items[j])
+		if nsI != nsJ {
+			return nsI < nsJ
+		}
+		return nameI < nameJ
+	})
+}
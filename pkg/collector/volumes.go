@@ -0,0 +1,125 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"kubeRadar/pkg/models"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// convertVolumes extracts the volumes of a pod spec together with the
+// containers mounting them
+func convertVolumes(spec corev1.PodSpec) []models.VolumeInfo {
+	mounts := make(map[string][]models.VolumeMountInfo)
+	addMounts := func(container string, volumeMounts []corev1.VolumeMount) {
+		for _, m := range volumeMounts {
+			mounts[m.Name] = append(mounts[m.Name], models.VolumeMountInfo{
+				Container: container,
+				MountPath: m.MountPath,
+				SubPath:   subPath(m),
+				ReadOnly:  m.ReadOnly,
+			})
+		}
+	}
+	for _, c := range spec.InitContainers {
+		addMounts(c.Name, c.VolumeMounts)
+	}
+	for _, c := range spec.Containers {
+		addMounts(c.Name, c.VolumeMounts)
+	}
+	for _, c := range spec.EphemeralContainers {
+		addMounts(c.Name, c.VolumeMounts)
+	}
+
+	volumes := make([]models.VolumeInfo, 0, len(spec.Volumes))
+	for _, v := range spec.Volumes {
+		volume := models.VolumeInfo{
+			Name:   v.Name,
+			Type:   volumeType(v.VolumeSource),
+			Mounts: mounts[v.Name],
+		}
+		if volume.Mounts == nil {
+			volume.Mounts = make([]models.VolumeMountInfo, 0)
+		}
+
+		src := v.VolumeSource
+		switch {
+		case src.HostPath != nil:
+			volume.Source = src.HostPath.Path
+		case src.EmptyDir != nil:
+			volume.Source = string(src.EmptyDir.Medium)
+		case src.Secret != nil:
+			volume.Source = src.Secret.SecretName
+			// Secret volumes are always mounted read-only
+			volume.ReadOnly = true
+		case src.ConfigMap != nil:
+			volume.Source = src.ConfigMap.Name
+			volume.ReadOnly = true
+		case src.PersistentVolumeClaim != nil:
+			volume.Source = src.PersistentVolumeClaim.ClaimName
+			volume.ReadOnly = src.PersistentVolumeClaim.ReadOnly
+		case src.CSI != nil:
+			volume.Source = src.CSI.Driver
+			volume.ReadOnly = src.CSI.ReadOnly != nil && *src.CSI.ReadOnly
+		case src.NFS != nil:
+			volume.Source = src.NFS.Server + ":" + src.NFS.Path
+			volume.ReadOnly = src.NFS.ReadOnly
+		case src.DownwardAPI != nil:
+			volume.ReadOnly = true
+		case src.Projected != nil:
+			volume.ReadOnly = true
+			sources := make([]string, 0, len(src.Projected.Sources))
+			for _, p := range src.Projected.Sources {
+				switch {
+				case p.ServiceAccountToken != nil:
+					sources = append(sources, "serviceAccountToken")
+					volume.ServiceAccountToken = true
+					volume.TokenAudience = p.ServiceAccountToken.Audience
+					volume.TokenExpirationSeconds = p.ServiceAccountToken.ExpirationSeconds
+				case p.Secret != nil:
+					sources = append(sources, "secret "+p.Secret.Name)
+				case p.ConfigMap != nil:
+					sources = append(sources, "configMap "+p.ConfigMap.Name)
+				case p.DownwardAPI != nil:
+					sources = append(sources, "downwardAPI")
+				case p.ClusterTrustBundle != nil:
+					sources = append(sources, "clusterTrustBundle")
+				}
+			}
+			volume.Source = strings.Join(sources, ", ")
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
+// volumeType returns the name of the volume source set in the pod spec,
+// e.g. hostPath or persistentVolumeClaim
+func volumeType(src corev1.VolumeSource) string {
+	out, err := json.Marshal(src)
+	if err != nil {
+		return "unknown"
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil || len(fields) == 0 {
+		return "unknown"
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// subPath returns the sub path of a mount, marking expanded sub paths
+func subPath(m corev1.VolumeMount) string {
+	if m.SubPathExpr != "" {
+		return fmt.Sprintf("%s (expr)", m.SubPathExpr)
+	}
+	return m.SubPath
+}
@@ -5,142 +5,357 @@ import (
 	"fmt"
 	"kubeRadar/pkg/models"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/pager"
 )
 
 func (c *Collector) collectWorkloadInfo(ctx context.Context) (models.WorkloadAssessment, error) {
 	workloads := models.WorkloadAssessment{
-		Pods:         make([]models.PodInfo, 0),
-		Deployments:  make([]models.DeploymentInfo, 0),
-		StatefulSets: make([]models.StatefulSetInfo, 0),
-		DaemonSets:   make([]models.DaemonSetInfo, 0),
+		Pods:                   make([]models.PodInfo, 0),
+		Deployments:            make([]models.DeploymentInfo, 0),
+		StatefulSets:           make([]models.StatefulSetInfo, 0),
+		DaemonSets:             make([]models.DaemonSetInfo, 0),
+		Jobs:                   make([]models.JobInfo, 0),
+		CronJobs:               make([]models.CronJobInfo, 0),
+		ReplicaSets:            make([]models.ReplicaSetInfo, 0),
+		ReplicationControllers: make([]models.ReplicationControllerInfo, 0),
 	}
 
-	namespaces, err := c.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return workloads, err
+	// Collect Pods
+	pods := listNamespaced[corev1.Pod](ctx, c, "Pods", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.CoreV1().Pods(namespace).List(ctx, opts)
+		}
+	})
+	for _, pod := range pods {
+		workloads.Pods = append(workloads.Pods, models.PodInfo{
+			Name:            pod.Name,
+			Namespace:       pod.Namespace,
+			NodeName:        pod.Spec.NodeName,
+			CreatedAt:       creationTime(pod.ObjectMeta),
+			Labels:          pod.Labels,
+			PodTemplateInfo: convertPodSpec(pod.ObjectMeta, pod.Spec),
+		})
 	}
 
-	for _, ns := range namespaces.Items {
-		// Collect Pods
-		pods, err := c.client.CoreV1().Pods(ns.Name).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue
+	// Collect Deployments
+	deployments := listNamespaced[appsv1.Deployment](ctx, c, "Deployments", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.AppsV1().Deployments(namespace).List(ctx, opts)
 		}
+	})
+	for _, deploy := range deployments {
+		workloads.Deployments = append(workloads.Deployments, models.DeploymentInfo{
+			Name:                         deploy.Name,
+			Namespace:                    deploy.Namespace,
+			Replicas:                     replicas(deploy.Spec.Replicas),
+			UpdateStrategy:               string(deploy.Spec.Strategy.Type),
+			ServiceAccount:               deploy.Spec.Template.Spec.ServiceAccountName,
+			AutomountServiceAccountToken: deploy.Spec.Template.Spec.AutomountServiceAccountToken,
+			Template:                     convertPodSpec(deploy.Spec.Template.ObjectMeta, deploy.Spec.Template.Spec),
+			Labels:                       deploy.Labels,
+			CreatedAt:                    creationTime(deploy.ObjectMeta),
+		})
+	}
 
-		for _, pod := range pods.Items {
-			containers := make([]models.ContainerInfo, 0)
-			for _, container := range pod.Spec.Containers {
-				securityContext := container.SecurityContext
-				var containerSecInfo models.ContainerSecurityInfo
-
-				if securityContext != nil {
-					containerSecInfo = models.ContainerSecurityInfo{
-						Capabilities:             getCapabilities(securityContext.Capabilities),
-						RunAsUser:                securityContext.RunAsUser,
-						RunAsNonRoot:             securityContext.RunAsNonRoot,
-						ReadOnlyRoot:             securityContext.ReadOnlyRootFilesystem != nil && *securityContext.ReadOnlyRootFilesystem,
-						Privileged:               securityContext.Privileged != nil && *securityContext.Privileged,
-						AllowPrivilegeEscalation: securityContext.AllowPrivilegeEscalation,
-					}
-				} // Collect environment variables
-				envVars := make([]string, 0)
-				for _, env := range container.Env {
-					envVars = append(envVars, env.Name)
-				}
-
-				containers = append(containers, models.ContainerInfo{
-					Name:            container.Name,
-					Image:           container.Image,
-					SecurityContext: containerSecInfo,
-					Resources: models.ResourceRequirements{
-						Limits: models.ResourceList{
-							CPU:    container.Resources.Limits.Cpu().String(),
-							Memory: container.Resources.Limits.Memory().String(),
-						},
-						Requests: models.ResourceList{
-							CPU:    container.Resources.Requests.Cpu().String(),
-							Memory: container.Resources.Requests.Memory().String(),
-						},
-					},
-					EnvVars: envVars,
-				})
-			}
-
-			podSecurity := pod.Spec.SecurityContext
-			var podSecInfo models.PodSecurityInfo
-			if podSecurity != nil {
-				podSecInfo = models.PodSecurityInfo{
-					RunAsUser:   podSecurity.RunAsUser,
-					RunAsGroup:  podSecurity.RunAsGroup,
-					FSGroup:     podSecurity.FSGroup,
-					HostNetwork: pod.Spec.HostNetwork,
-					HostPID:     pod.Spec.HostPID,
-					HostIPC:     pod.Spec.HostIPC}
-			}
-
-			workloads.Pods = append(workloads.Pods, models.PodInfo{
-				Name:                         pod.Name,
-				Namespace:                    pod.Namespace,
-				ServiceAccount:               pod.Spec.ServiceAccountName,
-				SecurityContext:              podSecInfo,
-				Containers:                   containers,
-				NodeName:                     pod.Spec.NodeName,
-				CreatedAt:                    pod.CreationTimestamp.String(),
-				Labels:                       pod.Labels,
-				AutomountServiceAccountToken: pod.Spec.AutomountServiceAccountToken,
-			})
+	// Collect StatefulSets
+	statefulSets := listNamespaced[appsv1.StatefulSet](ctx, c, "StatefulSets", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.AppsV1().StatefulSets(namespace).List(ctx, opts)
 		}
+	})
+	for _, sts := range statefulSets {
+		workloads.StatefulSets = append(workloads.StatefulSets, models.StatefulSetInfo{
+			Name:                         sts.Name,
+			Namespace:                    sts.Namespace,
+			Replicas:                     replicas(sts.Spec.Replicas),
+			UpdateStrategy:               string(sts.Spec.UpdateStrategy.Type),
+			ServiceAccount:               sts.Spec.Template.Spec.ServiceAccountName,
+			AutomountServiceAccountToken: sts.Spec.Template.Spec.AutomountServiceAccountToken,
+			Template:                     convertPodSpec(sts.Spec.Template.ObjectMeta, sts.Spec.Template.Spec),
+			Labels:                       sts.Labels,
+			CreatedAt:                    creationTime(sts.ObjectMeta),
+		})
+	}
 
-		// Collect Deployments
-		deployments, err := c.client.AppsV1().Deployments(ns.Name).List(ctx, metav1.ListOptions{})
-		if err == nil {
-			for _, deploy := range deployments.Items {
-				workloads.Deployments = append(workloads.Deployments, models.DeploymentInfo{
-					Name:           deploy.Name,
-					Namespace:      deploy.Namespace,
-					Replicas:       *deploy.Spec.Replicas,
-					UpdateStrategy: string(deploy.Spec.Strategy.Type),
-					Labels:         deploy.Labels,
-					CreatedAt:      deploy.CreationTimestamp.String(),
-				})
-			}
+	// Collect DaemonSets
+	daemonSets := listNamespaced[appsv1.DaemonSet](ctx, c, "DaemonSets", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.AppsV1().DaemonSets(namespace).List(ctx, opts)
 		}
+	})
+	for _, ds := range daemonSets {
+		workloads.DaemonSets = append(workloads.DaemonSets, models.DaemonSetInfo{
+			Name:                         ds.Name,
+			Namespace:                    ds.Namespace,
+			UpdateStrategy:               string(ds.Spec.UpdateStrategy.Type),
+			ServiceAccount:               ds.Spec.Template.Spec.ServiceAccountName,
+			AutomountServiceAccountToken: ds.Spec.Template.Spec.AutomountServiceAccountToken,
+			Template:                     convertPodSpec(ds.Spec.Template.ObjectMeta, ds.Spec.Template.Spec),
+			Labels:                       ds.Labels,
+			CreatedAt:                    creationTime(ds.ObjectMeta),
+		})
+	}
 
-		// Collect StatefulSets
-		statefulSets, err := c.client.AppsV1().StatefulSets(ns.Name).List(ctx, metav1.ListOptions{})
-		if err == nil {
-			for _, sts := range statefulSets.Items {
-				workloads.StatefulSets = append(workloads.StatefulSets, models.StatefulSetInfo{
-					Name:           sts.Name,
-					Namespace:      sts.Namespace,
-					Replicas:       *sts.Spec.Replicas,
-					UpdateStrategy: string(sts.Spec.UpdateStrategy.Type),
-					Labels:         sts.Labels,
-					CreatedAt:      sts.CreationTimestamp.String(),
-				})
-			}
+	// Collect Jobs
+	jobs := listNamespaced[batchv1.Job](ctx, c, "Jobs", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.BatchV1().Jobs(namespace).List(ctx, opts)
 		}
+	})
+	for _, job := range jobs {
+		workloads.Jobs = append(workloads.Jobs, models.JobInfo{
+			Name:         job.Name,
+			Namespace:    job.Namespace,
+			Owner:        controller(job.ObjectMeta),
+			Completions:  replicas(job.Spec.Completions),
+			Parallelism:  replicas(job.Spec.Parallelism),
+			BackoffLimit: int32Value(job.Spec.BackoffLimit, 6),
+			Active:       job.Status.Active,
+			Succeeded:    job.Status.Succeeded,
+			Failed:       job.Status.Failed,
+			Template:     convertPodSpec(job.Spec.Template.ObjectMeta, job.Spec.Template.Spec),
+			Labels:       job.Labels,
+			CreatedAt:    creationTime(job.ObjectMeta),
+		})
+	}
 
-		// Collect DaemonSets
-		daemonSets, err := c.client.AppsV1().DaemonSets(ns.Name).List(ctx, metav1.ListOptions{})
-		if err == nil {
-			for _, ds := range daemonSets.Items {
-				workloads.DaemonSets = append(workloads.DaemonSets, models.DaemonSetInfo{
-					Name:           ds.Name,
-					Namespace:      ds.Namespace,
-					UpdateStrategy: string(ds.Spec.UpdateStrategy.Type),
-					Labels:         ds.Labels,
-					CreatedAt:      ds.CreationTimestamp.String(),
-				})
-			}
+	// Collect CronJobs
+	cronJobs := listNamespaced[batchv1.CronJob](ctx, c, "CronJobs", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.BatchV1().CronJobs(namespace).List(ctx, opts)
 		}
+	})
+	for _, cj := range cronJobs {
+		workloads.CronJobs = append(workloads.CronJobs, models.CronJobInfo{
+			Name:              cj.Name,
+			Namespace:         cj.Namespace,
+			Schedule:          cj.Spec.Schedule,
+			Suspend:           cj.Spec.Suspend != nil && *cj.Spec.Suspend,
+			ConcurrencyPolicy: string(cj.Spec.ConcurrencyPolicy),
+			LastScheduleTime:  formatTime(cj.Status.LastScheduleTime),
+			Template:          convertPodSpec(cj.Spec.JobTemplate.Spec.Template.ObjectMeta, cj.Spec.JobTemplate.Spec.Template.Spec),
+			Labels:            cj.Labels,
+			CreatedAt:         creationTime(cj.ObjectMeta),
+		})
+	}
+
+	// Collect ReplicaSets
+	replicaSets := listNamespaced[appsv1.ReplicaSet](ctx, c, "ReplicaSets", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+		}
+	})
+	for _, rs := range replicaSets {
+		workloads.ReplicaSets = append(workloads.ReplicaSets, models.ReplicaSetInfo{
+			Name:                         rs.Name,
+			Namespace:                    rs.Namespace,
+			Owner:                        controller(rs.ObjectMeta),
+			Replicas:                     replicas(rs.Spec.Replicas),
+			ReadyReplicas:                rs.Status.ReadyReplicas,
+			ServiceAccount:               rs.Spec.Template.Spec.ServiceAccountName,
+			AutomountServiceAccountToken: rs.Spec.Template.Spec.AutomountServiceAccountToken,
+			Template:                     convertPodSpec(rs.Spec.Template.ObjectMeta, rs.Spec.Template.Spec),
+			Labels:                       rs.Labels,
+			CreatedAt:                    creationTime(rs.ObjectMeta),
+		})
+	}
+
+	// Collect ReplicationControllers
+	controllers := listNamespaced[corev1.ReplicationController](ctx, c, "ReplicationControllers", func(namespace string) pager.ListPageFunc {
+		return func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return c.client.CoreV1().ReplicationControllers(namespace).List(ctx, opts)
+		}
+	})
+	for _, rc := range controllers {
+		// The template of a replication controller is optional
+		template := corev1.PodTemplateSpec{}
+		if rc.Spec.Template != nil {
+			template = *rc.Spec.Template
+		}
+		workloads.ReplicationControllers = append(workloads.ReplicationControllers, models.ReplicationControllerInfo{
+			Name:                         rc.Name,
+			Namespace:                    rc.Namespace,
+			Replicas:                     replicas(rc.Spec.Replicas),
+			ReadyReplicas:                rc.Status.ReadyReplicas,
+			ServiceAccount:               template.Spec.ServiceAccountName,
+			AutomountServiceAccountToken: template.Spec.AutomountServiceAccountToken,
+			Template:                     convertPodSpec(template.ObjectMeta, template.Spec),
+			Labels:                       rc.Labels,
+			CreatedAt:                    creationTime(rc.ObjectMeta),
+		})
 	}
 
 	return workloads, nil
 }
 
+// convertPodSpec extracts the security-relevant settings of a pod or pod
+// template. The metadata carries the legacy AppArmor annotations.
+func convertPodSpec(meta metav1.ObjectMeta, spec corev1.PodSpec) models.PodTemplateInfo {
+	volumeRefs := volumeReferences(spec.Volumes)
+	containers := make([]models.ContainerInfo, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+	for _, container := range spec.InitContainers {
+		containerType := models.ContainerTypeInit
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			containerType = models.ContainerTypeSidecar
+		}
+		containers = append(containers, convertContainer(meta, container, containerType, volumeRefs))
+	}
+	for _, container := range spec.Containers {
+		containers = append(containers, convertContainer(meta, container, models.ContainerTypeRegular, volumeRefs))
+	}
+	for _, container := range spec.EphemeralContainers {
+		containers = append(containers, convertContainer(meta, corev1.Container(container.EphemeralContainerCommon), models.ContainerTypeEphemeral, volumeRefs))
+	}
+
+	// Host namespaces and the runtime class are part of the pod spec, not of
+	// its security context
+	podSecInfo := models.PodSecurityInfo{
+		HostNetwork:           spec.HostNetwork,
+		HostPID:               spec.HostPID,
+		HostIPC:               spec.HostIPC,
+		ShareProcessNamespace: spec.ShareProcessNamespace != nil && *spec.ShareProcessNamespace,
+	}
+	if spec.RuntimeClassName != nil {
+		podSecInfo.RuntimeClassName = *spec.RuntimeClassName
+	}
+	if podSecurity := spec.SecurityContext; podSecurity != nil {
+		podSecInfo.RunAsUser = podSecurity.RunAsUser
+		podSecInfo.RunAsGroup = podSecurity.RunAsGroup
+		podSecInfo.FSGroup = podSecurity.FSGroup
+		podSecInfo.SupplementalGroups = podSecurity.SupplementalGroups
+		for _, sysctl := range podSecurity.Sysctls {
+			podSecInfo.Sysctls = append(podSecInfo.Sysctls, models.Sysctl{Name: sysctl.Name, Value: sysctl.Value})
+		}
+		podSecInfo.SeccompProfile = seccompProfile(podSecurity.SeccompProfile)
+		podSecInfo.AppArmorProfile = appArmorProfile(podSecurity.AppArmorProfile)
+		podSecInfo.SELinuxOptions = seLinuxOptions(podSecurity.SELinuxOptions)
+		podSecInfo.WindowsOptions = windowsOptions(podSecurity.WindowsOptions)
+	}
+
+	pullSecrets := make([]string, 0, len(spec.ImagePullSecrets))
+	for _, ref := range spec.ImagePullSecrets {
+		pullSecrets = append(pullSecrets, ref.Name)
+	}
+
+	return models.PodTemplateInfo{
+		ServiceAccount:               spec.ServiceAccountName,
+		SecurityContext:              podSecInfo,
+		Containers:                   containers,
+		Volumes:                      convertVolumes(spec),
+		ImagePullSecrets:             pullSecrets,
+		AutomountServiceAccountToken: spec.AutomountServiceAccountToken,
+	}
+}
+
+// convertContainer extracts the security-relevant settings of a container.
+// volumeRefs holds the Secrets and ConfigMaps behind the pod volumes.
+func convertContainer(meta metav1.ObjectMeta, container corev1.Container, containerType string, volumeRefs map[string][]models.ConfigReference) models.ContainerInfo {
+	securityContext := container.SecurityContext
+	var containerSecInfo models.ContainerSecurityInfo
+
+	if securityContext != nil {
+		containerSecInfo = models.ContainerSecurityInfo{
+			Capabilities:             getCapabilities(securityContext.Capabilities),
+			RunAsUser:                securityContext.RunAsUser,
+			RunAsNonRoot:             securityContext.RunAsNonRoot,
+			ReadOnlyRoot:             securityContext.ReadOnlyRootFilesystem != nil && *securityContext.ReadOnlyRootFilesystem,
+			Privileged:               securityContext.Privileged != nil && *securityContext.Privileged,
+			AllowPrivilegeEscalation: securityContext.AllowPrivilegeEscalation,
+			SeccompProfile:           seccompProfile(securityContext.SeccompProfile),
+			AppArmorProfile:          appArmorProfile(securityContext.AppArmorProfile),
+			SELinuxOptions:           seLinuxOptions(securityContext.SELinuxOptions),
+			WindowsOptions:           windowsOptions(securityContext.WindowsOptions),
+		}
+		if securityContext.ProcMount != nil {
+			containerSecInfo.ProcMount = string(*securityContext.ProcMount)
+		}
+	}
+	// The field takes precedence over the annotation used before 1.30
+	if containerSecInfo.AppArmorProfile == "" {
+		containerSecInfo.AppArmorProfile = appArmorAnnotation(meta.Annotations[corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix+container.Name])
+	}
+
+	// Collect environment variables
+	envVars := make([]string, 0)
+	for _, env := range container.Env {
+		envVars = append(envVars, env.Name)
+	}
+	refs := configReferences(container)
+	for _, m := range container.VolumeMounts {
+		refs = append(refs, volumeRefs[m.Name]...)
+	}
+
+	ports := make([]models.ContainerPort, 0, len(container.Ports))
+	for _, p := range container.Ports {
+		ports = append(ports, models.ContainerPort{
+			Name:          p.Name,
+			ContainerPort: p.ContainerPort,
+			HostPort:      p.HostPort,
+			HostIP:        p.HostIP,
+			Protocol:      string(p.Protocol),
+		})
+	}
+
+	return models.ContainerInfo{
+		Name:            container.Name,
+		Type:            containerType,
+		Image:           container.Image,
+		ImagePullPolicy: string(container.ImagePullPolicy),
+		Command:         redactArgs(container.Command),
+		Args:            redactArgs(container.Args),
+		Ports:           ports,
+		Probes: models.ProbeInfo{
+			Liveness:  formatProbe(container.LivenessProbe),
+			Readiness: formatProbe(container.ReadinessProbe),
+			Startup:   formatProbe(container.StartupProbe),
+		},
+		SecurityContext: containerSecInfo,
+		Resources: models.ResourceRequirements{
+			Limits: models.ResourceList{
+				CPU:    container.Resources.Limits.Cpu().String(),
+				Memory: container.Resources.Limits.Memory().String(),
+			},
+			Requests: models.ResourceList{
+				CPU:    container.Resources.Requests.Cpu().String(),
+				Memory: container.Resources.Requests.Memory().String(),
+			},
+		},
+		EnvVars:          envVars,
+		ConfigReferences: refs,
+	}
+}
+
+// controller returns the controlling owner of an object as Kind/Name, or an
+// empty string for objects without one
+func controller(meta metav1.ObjectMeta) string {
+	owner := metav1.GetControllerOfNoCopy(&meta)
+	if owner == nil {
+		return ""
+	}
+	return owner.Kind + "/" + owner.Name
+}
+
+// int32Value dereferences an optional field, using def when it is unset
+func int32Value(v *int32, def int32) int32 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+// replicas returns the desired replica count, which defaults to 1 when unset
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
+
 func getCapabilities(capabilities *corev1.Capabilities) []string {
 	if capabilities == nil {
 		return nil
@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertPodSpecHostNamespacesWithoutSecurityContext(t *testing.T) {
+	spec := corev1.PodSpec{
+		HostNetwork: true,
+		HostPID:     true,
+		HostIPC:     true,
+		Containers:  []corev1.Container{{Name: "agent"}},
+	}
+	got := convertPodSpec(metav1.ObjectMeta{}, spec).SecurityContext
+	if !got.HostNetwork || !got.HostPID || !got.HostIPC {
+		t.Errorf("host namespaces not reported for a pod without securityContext: %+v", got)
+	}
+}
+
+func TestConvertPodSpecAppArmor(t *testing.T) {
+	meta := metav1.ObjectMeta{Annotations: map[string]string{
+		corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix + "legacy":   "localhost/custom",
+		corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix + "override": "unconfined",
+	}}
+	spec := corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "legacy"},
+		{Name: "override", SecurityContext: &corev1.SecurityContext{
+			AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeRuntimeDefault},
+		}},
+		{Name: "unset"},
+	}}
+
+	want := map[string]string{
+		"legacy":   "Localhost/custom",
+		"override": "RuntimeDefault",
+		"unset":    "",
+	}
+	for _, c := range convertPodSpec(meta, spec).Containers {
+		if got := c.SecurityContext.AppArmorProfile; got != want[c.Name] {
+			t.Errorf("%s: AppArmorProfile = %q, want %q", c.Name, got, want[c.Name])
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Package drift compares two assessments of the same cluster and reports the
+// objects and findings that were added, removed or modified in between.
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rules"
+)
+
+// Change types
+const (
+	Added    = "Added"
+	Removed  = "Removed"
+	Modified = "Modified"
+)
+
+// Change represents a single difference between two assessments
+// | Kind | Namespace | Name | Change | Fields | Details |
+type Change struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Change    string
+	Fields    []string // modified fields, e.g. SecurityContext.HostNetwork
+	Details   string
+}
+
+// KindSummary counts the changes of one kind
+// | Kind | Added | Removed | Modified |
+type KindSummary struct {
+	Kind     string
+	Added    int
+	Removed  int
+	Modified int
+}
+
+// Result is the outcome of comparing two assessments
+type Result struct {
+	Changes []Change
+	Summary []KindSummary
+	// Incomplete lists kinds that were not fully collected in either
+	// assessment, so their additions and removals may be collection gaps
+	Incomplete []string
+}
+
+// object is a comparable item of a kind
+type object struct {
+	namespace string
+	name      string
+	value     interface{}
+}
+
+// kind describes how to extract the objects of one kind from an assessment.
+// coverage is the kind name used in CoverageAssessment, empty if none.
+type kind struct {
+	name     string
+	coverage string
+	objects  func(data *models.AssessmentData) []object
+}
+
+// volatileFields are the runtime status fields of each model, which change
+// between two assessments of a healthy cluster and are not compared
+var volatileFields = map[reflect.Type]map[string]bool{
+	reflect.TypeOf(models.NodeInfo{}):                  {"Ready": true},
+	reflect.TypeOf(models.NamespaceInfo{}):             {"Status": true},
+	reflect.TypeOf(models.JobInfo{}):                   {"Active": true, "Succeeded": true, "Failed": true},
+	reflect.TypeOf(models.CronJobInfo{}):               {"LastScheduleTime": true},
+	reflect.TypeOf(models.ReplicaSetInfo{}):            {"ReadyReplicas": true},
+	reflect.TypeOf(models.ReplicationControllerInfo{}): {"ReadyReplicas": true},
+}
+
+func objects[T any](items []T, key func(T) (string, string)) []object {
+	out := make([]object, 0, len(items))
+	for _, item := range items {
+		namespace, name := key(item)
+		out = append(out, object{namespace: namespace, name: name, value: item})
+	}
+	return out
+}
+
+// keysOnly drops the values of the objects, so that they are only ever
+// added or removed, never modified
+func keysOnly(objects []object) []object {
+	for i := range objects {
+		objects[i].value = struct{}{}
+	}
+	return objects
+}
+
+// kinds lists every kind of the models package, in report order
+var kinds = []kind{
+	// Rules such as the escalation paths raise several findings with the
+	// same ID on one object, told apart by their title. Details such as the
+	// days left of a certificate change with time, so findings are only
+	// compared by their key.
+	{"Finding", "", func(d *models.AssessmentData) []object {
+		return keysOnly(objects(rules.Evaluate(d), func(f rules.Finding) (string, string) {
+			return f.Namespace, fmt.Sprintf("%s %s/%s: %s", f.ID, f.Kind, f.Name, f.Title)
+		}))
+	}},
+	{"Node", "Nodes", func(d *models.AssessmentData) []object {
+		return objects(d.ClusterInfo.Nodes, func(n models.NodeInfo) (string, string) { return "", n.Name })
+	}},
+	{"Namespace", "Namespaces", func(d *models.AssessmentData) []object {
+		return objects(d.ClusterInfo.Namespaces, func(n models.NamespaceInfo) (string, string) { return "", n.Name })
+	}},
+	{"Pod", "Pods", func(d *models.AssessmentData) []object {
+		return objects(d.Workloads.Pods, func(p models.PodInfo) (string, string) { return p.Namespace, p.Name })
+	}},
+	{"Deployment", "Deployments", func(d *models.AssessmentData) []object {
+		return objects(d.Workloads.Deployments, func(w models.DeploymentInfo) (string, string) { return w.Namespace, w.Name })
+	}},
+	{"StatefulSet", "StatefulSets", func(d *models.AssessmentData) []object {
+		return objects(d.Workloads.StatefulSets, func(w models.StatefulSetInfo) (string, string) { return w.Namespace, w.Name })
+	}},
+	{"DaemonSet", "DaemonSets", func(d *models.AssessmentData) []object {
+		return objects(d.Workloads.DaemonSets, func(w models.DaemonSetInfo) (string, string) { return w.Namespace, w.Name })
+	}},
+	{"Job", "Jobs", func(d *models.AssessmentData) []object {
+		return objects(d.Workloads.Jobs, func(w models.JobInfo) (string, string) { return w.Namespace, w.Name })
+	}},
+	{"CronJob", "CronJobs", func(d *models.AssessmentData) []object {
+		return objects(d.Workloads.CronJobs, func(w models.CronJobInfo) (string, string) { return w.Namespace, w.Name })
+	}},
+	{"ReplicaSet", "ReplicaSets", func(d *models.AssessmentData) []object {
+		return objects(d.Workloads.ReplicaSets, func(w models.ReplicaSetInfo) (string, string) { return w.Namespace, w.Name })
+	}},
+	{"ReplicationController", "ReplicationControllers", func(d *models.AssessmentData) []object {
+		return objects(d.Workloads.ReplicationControllers, func(w models.ReplicationControllerInfo) (string, string) { return w.Namespace, w.Name })
+	}},
+	{"Service", "Services", func(d *models.AssessmentData) []object {
+		return objects(d.Network.Services, func(s models.ServiceInfo) (string, string) { return s.Namespace, s.Name })
+	}},
+	{"NetworkPolicy", "NetworkPolicies", func(d *models.AssessmentData) []object {
+		return objects(d.Network.NetworkPolicies, func(p models.NetworkPolicyInfo) (string, string) { return p.Namespace, p.Name })
+	}},
+	{"Ingress", "Ingresses", func(d *models.AssessmentData) []object {
+		return objects(d.Network.Ingresses, func(i models.IngressInfo) (string, string) { return i.Namespace, i.Name })
+	}},
+	{"Secret", "Secrets", func(d *models.AssessmentData) []object {
+		return objects(d.Secrets.Secrets, func(s models.SecretInfo) (string, string) { return s.Namespace, s.Name })
+	}},
+	{"ConfigMap", "ConfigMaps", func(d *models.AssessmentData) []object {
+		return objects(d.ConfigMaps.ConfigMaps, func(c models.ConfigMapInfo) (string, string) { return c.Namespace, c.Name })
+	}},
+	{"ServiceAccount", "ServiceAccounts", func(d *models.AssessmentData) []object {
+		return objects(d.RBAC.ServiceAccounts, func(s models.ServiceAccountInfo) (string, string) { return s.Namespace, s.Name })
+	}},
+	{"Role", "Roles", func(d *models.AssessmentData) []object {
+		return objects(d.RBAC.Roles, func(r models.RoleInfo) (string, string) { return r.Namespace, r.Name })
+	}},
+	{"RoleBinding", "RoleBindings", func(d *models.AssessmentData) []object {
+		return objects(d.RBAC.RoleBindings, func(b models.BindingInfo) (string, string) { return b.Namespace, b.Name })
+	}},
+	{"ClusterRole", "ClusterRoles", func(d *models.AssessmentData) []object {
+		return objects(d.RBAC.ClusterRoles, func(r models.RoleInfo) (string, string) { return "", r.Name })
+	}},
+	{"ClusterRoleBinding", "ClusterRoleBindings", func(d *models.AssessmentData) []object {
+		return objects(d.RBAC.ClusterRoleBindings, func(b models.BindingInfo) (string, string) { return "", b.Name })
+	}},
+}
+
+// Compare reports the changes from the old to the new assessment
+func Compare(old, new *models.AssessmentData) Result {
+	result := Result{
+		Changes:    make([]Change, 0),
+		Summary:    make([]KindSummary, 0, len(kinds)),
+		Incomplete: make([]string, 0),
+	}
+	incomplete := make(map[string]bool)
+	for _, data := range []*models.AssessmentData{old, new} {
+		for _, k := range data.Coverage.Incomplete() {
+			incomplete[k.Kind] = true
+		}
+	}
+
+	for _, k := range kinds {
+		changes := compareKind(k, k.objects(old), k.objects(new))
+		summary := KindSummary{Kind: k.name}
+		for _, change := range changes {
+			switch change.Change {
+			case Added:
+				summary.Added++
+			case Removed:
+				summary.Removed++
+			case Modified:
+				summary.Modified++
+			}
+		}
+		result.Changes = append(result.Changes, changes...)
+		result.Summary = append(result.Summary, summary)
+		if incomplete[k.coverage] {
+			result.Incomplete = append(result.Incomplete, k.name)
+		}
+	}
+	return result
+}
+
+func compareKind(k kind, old, new []object) []Change {
+	key := func(o object) string { return o.namespace + "/" + o.name }
+	oldByKey := make(map[string]object, len(old))
+	for _, o := range old {
+		oldByKey[key(o)] = o
+	}
+	newByKey := make(map[string]object, len(new))
+	for _, o := range new {
+		newByKey[key(o)] = o
+	}
+
+	changes := make([]Change, 0)
+	for key, n := range newByKey {
+		o, ok := oldByKey[key]
+		if !ok {
+			changes = append(changes, Change{Kind: k.name, Namespace: n.namespace, Name: n.name, Change: Added})
+			continue
+		}
+		fields, details := diffFields("", reflect.ValueOf(o.value), reflect.ValueOf(n.value))
+		if len(fields) > 0 {
+			changes = append(changes, Change{
+				Kind:      k.name,
+				Namespace: n.namespace,
+				Name:      n.name,
+				Change:    Modified,
+				Fields:    fields,
+				Details:   strings.Join(details, "; "),
+			})
+		}
+	}
+	for key, o := range oldByKey {
+		if _, ok := newByKey[key]; !ok {
+			changes = append(changes, Change{Kind: k.name, Namespace: o.namespace, Name: o.name, Change: Removed})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Namespace != changes[j].Namespace {
+			return changes[i].Namespace < changes[j].Namespace
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// diffFields walks two values of the same struct type and returns the paths
+// of the fields that differ, with a readable description of each change.
+// Nested structs are compared field by field, everything else as a whole.
+// volatileFields are skipped.
+func diffFields(prefix string, old, new reflect.Value) ([]string, []string) {
+	fields := make([]string, 0)
+	details := make([]string, 0)
+	t := old.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if volatileFields[t][field.Name] {
+			continue
+		}
+		path := prefix + field.Name
+		if field.Anonymous {
+			path = prefix
+		}
+		o, n := old.Field(i), new.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			if !field.Anonymous {
+				path += "."
+			}
+			f, d := diffFields(path, o, n)
+			fields = append(fields, f...)
+			details = append(details, d...)
+			continue
+		}
+
+		oldText, newText := encode(o), encode(n)
+		if oldText == newText {
+			continue
+		}
+		fields = append(fields, path)
+		if isScalar(field.Type) {
+			details = append(details, fmt.Sprintf("%s: %s -> %s", path, oldText, newText))
+		} else {
+			details = append(details, fmt.Sprintf("%s changed", path))
+		}
+	}
+	return fields, details
+}
+
+// encode renders a field value for comparison. Empty and nil collections are
+// treated alike, since older snapshots may encode them either way.
+func encode(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return "unset"
+	}
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0 {
+		return "none"
+	}
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.String {
+		if v.String() == "" {
+			return `""`
+		}
+		return v.String()
+	}
+	out, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	return string(out)
+}
+
+func isScalar(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct, reflect.Array:
+		return false
+	}
+	return true
+}
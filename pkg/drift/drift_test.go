@@ -0,0 +1,169 @@
+package drift
+
+import (
+	"reflect"
+	"testing"
+
+	"kubeRadar/pkg/collector"
+	"kubeRadar/pkg/fixture"
+	"kubeRadar/pkg/models"
+
+	"k8s.io/client-go/rest"
+)
+
+func collectFixture(t *testing.T) *models.AssessmentData {
+	t.Helper()
+	c := collector.NewCollectorForClients(collector.Clients{Kube: fixture.Clientset()}, &rest.Config{Host: "https://fixture.example.com:6443"}, collector.DefaultOptions())
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+	return data
+}
+
+func find(changes []Change, kind, namespace, name string) *Change {
+	for i, c := range changes {
+		if c.Kind == kind && c.Namespace == namespace && c.Name == name {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func TestCompareUnchanged(t *testing.T) {
+	result := Compare(collectFixture(t), collectFixture(t))
+	if len(result.Changes) != 0 {
+		t.Errorf("got %d changes between identical assessments: %+v", len(result.Changes), result.Changes)
+	}
+	if len(result.Summary) != len(kinds) {
+		t.Errorf("got %d summary rows, want one per kind (%d)", len(result.Summary), len(kinds))
+	}
+}
+
+func TestCompare(t *testing.T) {
+	old := collectFixture(t)
+	new := collectFixture(t)
+
+	new.RBAC.ClusterRoleBindings = append(new.RBAC.ClusterRoleBindings, models.BindingInfo{
+		Name:     "contractor-admin",
+		RoleRef:  models.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects: []models.Subject{{Kind: "User", Name: "bob@example.com"}},
+	})
+	var pod *models.PodInfo
+	for i := range new.Workloads.Pods {
+		if new.Workloads.Pods[i].Name == "api-7d9f8" {
+			pod = &new.Workloads.Pods[i]
+		}
+	}
+	pod.Containers[0].SecurityContext.Privileged = true
+	pod.SecurityContext.HostNetwork = true
+	new.Network.Services = append(new.Network.Services, models.ServiceInfo{Name: "db-public", Namespace: "payments", Type: "LoadBalancer"})
+	new.Network.NetworkPolicies = nil
+
+	result := Compare(old, new)
+
+	if c := find(result.Changes, "ClusterRoleBinding", "", "contractor-admin"); c == nil || c.Change != Added {
+		t.Errorf("new cluster-admin binding not reported as added: %+v", c)
+	}
+	c := find(result.Changes, "Pod", pod.Namespace, pod.Name)
+	if c == nil || c.Change != Modified {
+		t.Fatalf("privileged pod not reported as modified: %+v", c)
+	}
+	if want := []string{"SecurityContext.HostNetwork", "Containers"}; !reflect.DeepEqual(c.Fields, want) {
+		t.Errorf("got modified fields %v, want %v", c.Fields, want)
+	}
+	if c := find(result.Changes, "Service", "payments", "db-public"); c == nil || c.Change != Added {
+		t.Errorf("new LoadBalancer service not reported as added: %+v", c)
+	}
+	if c := find(result.Changes, "NetworkPolicy", "payments", "default-deny"); c == nil || c.Change != Removed {
+		t.Errorf("deleted NetworkPolicy not reported as removed: %+v", c)
+	}
+	if c := find(result.Changes, "Finding", "", "KR-RBAC-003 ClusterRoleBinding/contractor-admin: cluster-admin granted cluster-wide"); c == nil || c.Change != Added {
+		t.Errorf("new cluster-admin finding not reported as added: %+v", c)
+	}
+	if c := find(result.Changes, "Finding", "", "KR-NET-001 Namespace/payments: Namespace without NetworkPolicy"); c == nil || c.Change != Added {
+		t.Errorf("namespace losing its NetworkPolicy not reported as a new finding: %+v", c)
+	}
+}
+
+func TestCompareFindingsOnSameObject(t *testing.T) {
+	data := func(resources ...string) *models.AssessmentData {
+		d := &models.AssessmentData{}
+		d.RBAC.ClusterRoles = []models.RoleInfo{{Name: "debugger", ClusterRole: true, Rules: []models.PolicyRule{
+			{APIGroups: []string{""}, Resources: resources, Verbs: []string{"get"}},
+		}}}
+		d.RBAC.ClusterRoleBindings = []models.BindingInfo{{
+			Name: "debugger", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "debugger"},
+			Subjects: []models.Subject{{Kind: "User", Name: "dev"}},
+		}}
+		return d
+	}
+	// Both escalation paths of User dev are KR-RBAC-006 findings on one object
+	result := Compare(data("secrets", "nodes/proxy"), data("secrets"))
+	findings := make([]Change, 0)
+	for _, c := range result.Changes {
+		if c.Kind == "Finding" {
+			findings = append(findings, c)
+		}
+	}
+	want := []Change{{Kind: "Finding", Name: "KR-RBAC-006 User/dev: Privilege escalation path to node compromise", Change: Removed}}
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("got finding changes %+v, want %+v", findings, want)
+	}
+}
+
+func TestCompareIgnoresStatus(t *testing.T) {
+	old := collectFixture(t)
+	new := collectFixture(t)
+	// A certificate expiring soon is reported with the days it has left
+	cert := models.SecretInfo{
+		CommonInfo:   models.CommonInfo{Name: "soon-tls", Namespace: "payments"},
+		Type:         "kubernetes.io/tls",
+		Certificates: []models.CertificateInfo{{Subject: "CN=soon", NotAfter: fixture.CollectedAt.AddDate(0, 0, 20).String()}},
+	}
+	old.Secrets.Secrets = append(old.Secrets.Secrets, cert)
+	new.Secrets.Secrets = append(new.Secrets.Secrets, cert)
+	old.ClusterInfo.CollectedAt = fixture.CollectedAt.String()
+	new.ClusterInfo.CollectedAt = fixture.CollectedAt.AddDate(0, 0, 1).String()
+
+	for i := range new.ClusterInfo.Nodes {
+		new.ClusterInfo.Nodes[i].Ready = !new.ClusterInfo.Nodes[i].Ready
+	}
+	for i := range new.ClusterInfo.Namespaces {
+		new.ClusterInfo.Namespaces[i].Status = "Terminating"
+	}
+	for i := range new.Workloads.Jobs {
+		new.Workloads.Jobs[i].Active++
+		new.Workloads.Jobs[i].Succeeded++
+		new.Workloads.Jobs[i].Failed++
+	}
+	for i := range new.Workloads.CronJobs {
+		new.Workloads.CronJobs[i].LastScheduleTime = new.ClusterInfo.CollectedAt
+	}
+	for i := range new.Workloads.ReplicaSets {
+		new.Workloads.ReplicaSets[i].ReadyReplicas++
+	}
+	if len(new.Workloads.Jobs) == 0 || len(new.Workloads.CronJobs) == 0 || len(new.Workloads.ReplicaSets) == 0 {
+		t.Fatal("fixture has no Jobs, CronJobs or ReplicaSets")
+	}
+
+	if result := Compare(old, new); len(result.Changes) != 0 {
+		t.Errorf("got changes between assessments differing in status only: %+v", result.Changes)
+	}
+}
+
+func TestCompareFlagsIncompleteKinds(t *testing.T) {
+	old := collectFixture(t)
+	new := collectFixture(t)
+	for i := range new.Coverage.Kinds {
+		if new.Coverage.Kinds[i].Kind == "Secrets" {
+			new.Coverage.Kinds[i].Status = models.CoverageFailed
+		}
+	}
+	new.Secrets.Secrets = nil
+
+	result := Compare(old, new)
+	if !reflect.DeepEqual(result.Incomplete, []string{"Secret"}) {
+		t.Errorf("got incomplete kinds %v, want [Secret]", result.Incomplete)
+	}
+}
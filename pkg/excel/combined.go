@@ -0,0 +1,104 @@
+package excel
+
+import (
+	"fmt"
+
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rules"
+)
+
+// ClusterReport pairs a cluster with its collected data
+type ClusterReport struct {
+	Name string
+	Data *models.AssessmentData
+}
+
+// GenerateCombined writes a single workbook merging the data of several
+// clusters. It starts with a Clusters overview, followed by every table sheet
+// of the per-cluster report with a leading Cluster column.
+func GenerateCombined(filePath string, clusters []ClusterReport) error {
+	r, err := NewReport(filePath)
+	if err != nil {
+		return err
+	}
+
+	tables := combinedTables(clusters)
+	sheets := []string{"Clusters"}
+	for _, t := range tables {
+		if t.Sheet != sheets[len(sheets)-1] {
+			sheets = append(sheets, t.Sheet)
+		}
+	}
+	for i, sheet := range sheets {
+		if i == 0 {
+			r.excel.SetSheetName("Sheet1", sheet)
+		} else {
+			r.excel.NewSheet(sheet)
+		}
+	}
+
+	if err := r.writeTables("Clusters", []Table{clustersTable(clusters)}); err != nil {
+		return fmt.Errorf("failed to generate cluster overview: %v", err)
+	}
+	for _, sheet := range sheets[1:] {
+		if err := r.writeTables(sheet, tables); err != nil {
+			return fmt.Errorf("failed to merge %s: %v", sheet, err)
+		}
+	}
+
+	if err := r.excel.SaveAs(r.filePath); err != nil {
+		return fmt.Errorf("failed to save excel file: %v", err)
+	}
+	return nil
+}
+
+// Clusters pane
+func clustersTable(clusters []ClusterReport) Table {
+	t := Table{Sheet: "Clusters", Headers: []string{
+		"Cluster", "Version", "API Server", "Nodes", "Namespaces", "Pods",
+		"Critical", "High", "Medium", "Low", "Incomplete Kinds",
+	}}
+	for _, cluster := range clusters {
+		data := cluster.Data
+		counts := rules.CountBySeverity(rules.Evaluate(data))
+		t.addRow(
+			cluster.Name,
+			data.ClusterInfo.Version,
+			data.ClusterInfo.APIServer,
+			data.ClusterInfo.NodeCount,
+			len(data.ClusterInfo.Namespaces),
+			len(data.Workloads.Pods),
+			counts[rules.SeverityCritical],
+			counts[rules.SeverityHigh],
+			counts[rules.SeverityMedium],
+			counts[rules.SeverityLow],
+			len(data.Coverage.Incomplete()),
+		)
+	}
+	return t
+}
+
+// combinedTables merges the report tables of every cluster table by table,
+// prefixing each row with the cluster name. The first table of each sheet
+// gets an auto filter.
+func combinedTables(clusters []ClusterReport) []Table {
+	merged := make([]Table, 0)
+	for i, cluster := range clusters {
+		for k, t := range Tables(cluster.Data) {
+			if i == 0 {
+				first := k == 0 || merged[k-1].Sheet != t.Sheet
+				merged = append(merged, Table{
+					Sheet:   t.Sheet,
+					Title:   t.Title,
+					Headers: append([]string{"Cluster"}, t.Headers...),
+					Rows:    make([][]Cell, 0),
+					filter:  first,
+				})
+			}
+			for _, row := range t.Rows {
+				merged[k].Rows = append(merged[k].Rows, append([]Cell{{Value: cluster.Name}}, row...))
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,52 @@
+package excel
+
+import "kubeRadar/pkg/models"
+
+// coverageLevel returns the highlight level of a coverage status
+func coverageLevel(status string) string {
+	switch status {
+	case models.CoverageComplete:
+		return LevelGood
+	case models.CoveragePartial:
+		return LevelWarning
+	default:
+		return LevelCritical
+	}
+}
+
+// Collection Coverage pane
+func coverageTable(coverage models.CoverageAssessment) Table {
+	t := Table{Sheet: "Collection Coverage", Headers: []string{"Kind", "Status", "Collected", "Failed Namespaces"}}
+	for _, kind := range coverage.Kinds {
+		row := t.addRow(
+			kind.Kind,
+			kind.Status,
+			kind.Collected,
+			kind.FailedNamespaces,
+		)
+		t.highlight(row, "Status", coverageLevel(kind.Status))
+	}
+	return t
+}
+
+// collectionErrorsTable lists the errors that caused the coverage gaps,
+// below the coverage summary
+func collectionErrorsTable(coverage models.CoverageAssessment) Table {
+	t := Table{Sheet: "Collection Coverage", Title: "Collection Errors", Headers: []string{"Kind", "Namespace", "Reason", "Message"}}
+	if len(coverage.Errors) == 0 {
+		t.Rows = append(t.Rows, messageRow(len(t.Headers), "No collection errors", LevelGood))
+	}
+	for _, e := range coverage.Errors {
+		namespace := e.Namespace
+		if namespace == "" {
+			namespace = "(cluster-wide)"
+		}
+		t.addRow(
+			e.Kind,
+			namespace,
+			e.Reason,
+			e.Message,
+		)
+	}
+	return t
+}
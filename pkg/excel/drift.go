@@ -0,0 +1,63 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeRadar/pkg/drift"
+)
+
+// changeLevel returns the highlight level of a change type
+func changeLevel(change string) string {
+	switch change {
+	case drift.Added:
+		return LevelModerate
+	case drift.Removed:
+		return LevelCritical
+	default:
+		return LevelWarning
+	}
+}
+
+// GenerateDrift writes a workbook with a single Drift sheet listing the
+// changes between two assessments
+func (r *Report) GenerateDrift(result drift.Result) error {
+	r.excel.SetSheetName("Sheet1", "Drift")
+	if err := r.writeTables("Drift", []Table{driftTable(result)}); err != nil {
+		return fmt.Errorf("failed to generate drift: %v", err)
+	}
+	if err := r.excel.SaveAs(r.filePath); err != nil {
+		return fmt.Errorf("failed to save excel file: %v", err)
+	}
+	return nil
+}
+
+// Drift pane
+func driftTable(result drift.Result) Table {
+	t := Table{Sheet: "Drift", Headers: []string{"Change", "Kind", "Namespace", "Name", "Changed Fields", "Details"}, filter: true}
+
+	incomplete := make(map[string]bool)
+	for _, kind := range result.Incomplete {
+		incomplete[kind] = true
+	}
+
+	if len(result.Changes) == 0 {
+		t.Rows = append(t.Rows, messageRow(len(t.Headers), "No changes", LevelGood))
+	}
+	for _, c := range result.Changes {
+		details := c.Details
+		if incomplete[c.Kind] && c.Change != drift.Modified {
+			details = "Kind was not fully collected in one of the assessments, this may be a collection gap"
+		}
+		row := t.addRow(
+			c.Change,
+			c.Kind,
+			c.Namespace,
+			c.Name,
+			strings.Join(c.Fields, ", "),
+			details,
+		)
+		t.highlight(row, "Change", changeLevel(c.Change))
+	}
+	return t
+}
@@ -0,0 +1,39 @@
+package excel
+
+import "kubeRadar/pkg/rules"
+
+// SeverityLevel returns the highlight level of a severity
+func SeverityLevel(sev rules.Severity) string {
+	switch sev {
+	case rules.SeverityCritical:
+		return LevelCritical
+	case rules.SeverityHigh:
+		return LevelWarning
+	case rules.SeverityMedium:
+		return LevelModerate
+	default:
+		return LevelLow
+	}
+}
+
+// Findings pane
+func findingsTable(findings []rules.Finding) Table {
+	t := Table{Sheet: "Findings", Headers: []string{"ID", "Severity", "Category", "Kind", "Namespace", "Name", "Title", "Detail", "Remediation"}, filter: true}
+	for _, f := range findings {
+		row := t.addRow(
+			f.ID,
+			string(f.Severity),
+			f.Category,
+			f.Kind,
+			f.Namespace,
+			f.Name,
+			f.Title,
+			f.Detail,
+			f.Remediation,
+		)
+		for i := range row {
+			row[i].Level = SeverityLevel(f.Severity)
+		}
+	}
+	return t
+}
@@ -0,0 +1,77 @@
+package excel
+
+import (
+	"sort"
+
+	"kubeRadar/pkg/models"
+)
+
+// hostPort is a port of a pod that is reachable on the node it runs on
+type hostPort struct {
+	node          string
+	hostIP        string
+	port          int32
+	protocol      string
+	pod           string
+	namespace     string
+	container     string
+	containerPort int32
+	source        string
+}
+
+// hostPorts lists the ports bound on nodes, either through a hostPort or
+// because the pod uses the host network, ordered by node and port
+func hostPorts(pods []models.PodInfo) []hostPort {
+	ports := make([]hostPort, 0)
+	for _, pod := range pods {
+		for _, c := range pod.Containers {
+			for _, p := range c.Ports {
+				hp := hostPort{
+					node:          pod.NodeName,
+					hostIP:        firstNonEmpty(p.HostIP, "0.0.0.0"),
+					port:          p.HostPort,
+					protocol:      p.Protocol,
+					pod:           pod.Name,
+					namespace:     pod.Namespace,
+					container:     c.Name,
+					containerPort: p.ContainerPort,
+					source:        "hostPort",
+				}
+				switch {
+				case pod.SecurityContext.HostNetwork:
+					hp.port = p.ContainerPort
+					hp.source = "hostNetwork"
+				case p.HostPort == 0:
+					continue
+				}
+				ports = append(ports, hp)
+			}
+		}
+	}
+	sort.SliceStable(ports, func(i, j int) bool {
+		if ports[i].node != ports[j].node {
+			return ports[i].node < ports[j].node
+		}
+		return ports[i].port < ports[j].port
+	})
+	return ports
+}
+
+// hostPortsTable lists the host port exposure below the nodes
+func hostPortsTable(pods []models.PodInfo) Table {
+	t := Table{Sheet: "Nodes", Title: "Host Port Exposure", Headers: []string{"Node", "Host IP", "Host Port", "Protocol", "Pod", "Namespace", "Container", "Container Port", "Source"}}
+	for _, hp := range hostPorts(pods) {
+		t.addRow(
+			hp.node,
+			hp.hostIP,
+			hp.port,
+			hp.protocol,
+			hp.pod,
+			hp.namespace,
+			hp.container,
+			hp.containerPort,
+			hp.source,
+		)
+	}
+	return t
+}
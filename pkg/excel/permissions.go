@@ -0,0 +1,109 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rbac"
+	"kubeRadar/pkg/rules"
+)
+
+// effectivePermissionsTable lists every rule each subject receives through
+// a binding, one row per subject, scope and rule. Grants enabling an
+// escalation technique are highlighted.
+func effectivePermissionsTable(data *models.AssessmentData, escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "Effective Permissions", Headers: []string{"Subject Kind", "Subject", "Scope", "API Groups", "Resources", "Resource Names", "Non-Resource URLs", "Verbs", "Via", "Escalation Risk"}, filter: true}
+	for _, grant := range rbac.NewResolver(data.RBAC).Grants() {
+		risks := escalation.Risks(grant)
+		subject := grant.Subject.Name
+		if grant.Subject.Kind == rbac.KindServiceAccount {
+			subject = grant.Subject.Namespace + "/" + grant.Subject.Name
+		}
+		row := t.addRow(
+			grant.Subject.Kind,
+			subject,
+			PermissionScope(grant),
+			strings.Join(grant.Rule.APIGroups, ", "),
+			strings.Join(grant.Rule.Resources, ", "),
+			strings.Join(grant.Rule.ResourceNames, ", "),
+			strings.Join(grant.Rule.NonResourceURLs, ", "),
+			strings.Join(grant.Rule.Verbs, ", "),
+			grant.Via(),
+			strings.Join(risks, ", "),
+		)
+		if len(risks) > 0 {
+			t.highlight(row, "Escalation Risk", LevelWarning)
+		}
+	}
+	return t
+}
+
+// escalationPathsTable lists the shortest escalation path from every
+// subject to each outcome it can reach, colored by severity
+func escalationPathsTable(escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "Escalation Paths", Headers: []string{"Severity", "Subject Kind", "Subject", "Outcome", "Steps", "Path"}, filter: true}
+	for _, path := range escalation.Paths() {
+		subject := path.Subject.Name
+		if path.Subject.Kind == rbac.KindServiceAccount {
+			subject = path.Subject.Namespace + "/" + path.Subject.Name
+		}
+		row := t.addRow(
+			string(path.Severity),
+			path.Subject.Kind,
+			subject,
+			path.Target(),
+			len(path.Steps),
+			path.Describe(),
+		)
+		t.highlight(row, "Severity", SeverityLevel(path.Severity))
+	}
+	return t
+}
+
+// PermissionScope describes where a grant applies
+func PermissionScope(grant rbac.Grant) string {
+	if grant.ClusterWide() {
+		return "cluster-wide"
+	}
+	return "namespace " + grant.Namespace
+}
+
+// formatWorkloadAccess lists the permissions of the service account token a
+// workload mounts and the escalation outcomes they reach, one per line. The
+// public version and health endpoints every client can read are left out.
+func formatWorkloadAccess(access rules.WorkloadAccess) (string, string) {
+	if !access.TokenMounted {
+		return "None (no token mounted)", ""
+	}
+	permissions := make([]string, 0, len(access.Grants))
+	seen := make(map[string]bool)
+	for _, grant := range access.Grants {
+		if rules.IsPublicGrant(grant) {
+			continue
+		}
+		resources := append(append([]string{}, grant.Rule.Resources...), grant.Rule.NonResourceURLs...)
+		permission := fmt.Sprintf("%s on %s (%s)", strings.Join(grant.Rule.Verbs, ", "), strings.Join(resources, ", "), PermissionScope(grant))
+		if !seen[permission] {
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+	if len(permissions) == 0 {
+		permissions = append(permissions, "None")
+	}
+	targets := make([]string, 0, len(access.Paths))
+	for _, path := range access.Paths {
+		targets = append(targets, path.Target())
+	}
+	return strings.Join(permissions, "\n"), strings.Join(targets, "\n")
+}
+
+// workloadAccessLevel colors the escalation cell by the most severe outcome
+// the token reaches
+func workloadAccessLevel(access rules.WorkloadAccess) string {
+	if len(access.Paths) > 0 {
+		return SeverityLevel(access.Paths[0].Severity)
+	}
+	return ""
+}
@@ -3,11 +3,17 @@ package excel
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"kubeRadar/pkg/assets"
+	"kubeRadar/pkg/graph"
 	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rbac"
+	"kubeRadar/pkg/rules"
+	"kubeRadar/pkg/summary"
 
 	"github.com/xuri/excelize/v2"
 
@@ -30,6 +36,7 @@ type Report struct {
 	criticalStyle int
 	warningStyle  int
 	moderateStyle int
+	lowStyle      int
 	goodStyle     int
 
 	// Chart styles
@@ -243,6 +250,24 @@ func (r *Report) setupStyles() error {
 		return fmt.Errorf("failed to create moderate style: %v", err)
 	}
 
+	// Low style - Light blue background for low severity issues
+	r.lowStyle, err = r.excel.NewStyle(&excelize.Style{
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Color:   []string{"DDEBF7"},
+			Pattern: 1,
+		},
+		Border: []excelize.Border{
+			{Type: "top", Color: "000000", Style: 1},
+			{Type: "bottom", Color: "000000", Style: 1},
+			{Type: "left", Color: "000000", Style: 1},
+			{Type: "right", Color: "000000", Style: 1},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create low style: %v", err)
+	}
+
 	// Good style - Green background for good status
 	r.goodStyle, err = r.excel.NewStyle(&excelize.Style{
 		Fill: excelize.Fill{
@@ -307,7 +332,7 @@ func (r *Report) setupStyles() error {
 }
 
 // formatLabels formats a map of labels into a string
-func (r *Report) formatLabels(labels map[string]string) string {
+func formatLabels(labels map[string]string) string {
 	if len(labels) == 0 {
 		return ""
 	}
@@ -355,7 +380,22 @@ func (r *Report) autoFitColumns(sheet string) error {
 	return nil
 }
 
+// Generate builds the report from the data and saves it to the file path
 func (r *Report) Generate(data *models.AssessmentData) error {
+	if err := r.build(data); err != nil {
+		return err
+	}
+
+	// Save the file
+	if err := r.excel.SaveAs(r.filePath); err != nil {
+		return fmt.Errorf("failed to save excel file: %v", err)
+	}
+
+	return nil
+}
+
+// build writes every sheet of the report into the in-memory workbook
+func (r *Report) build(data *models.AssessmentData) error {
 	// Setup styles first
 	if err := r.setupStyles(); err != nil {
 		return err
@@ -364,21 +404,31 @@ func (r *Report) Generate(data *models.AssessmentData) error {
 	sheets := []string{
 		"Contents",
 		"Dashboard",
+		"Findings",
 		"Nodes",
 		"Namespaces",
 		"Pods",
+		"Volumes",
 		"Deployments",
 		"StatefulSets",
 		"DaemonSets",
+		"Jobs",
+		"CronJobs",
+		"ReplicaSets",
+		"Replication Controllers",
 		"Services",
 		"Network Policies",
 		"Ingresses",
 		"Secrets",
+		"ConfigMaps",
 		"Service Accounts",
 		"Roles",
 		"Role Bindings",
 		"Cluster Roles",
 		"Cluster Role Bindings",
+		"Effective Permissions",
+		"Escalation Paths",
+		"Collection Coverage",
 	}
 
 	// Initialize sheets
@@ -395,54 +445,15 @@ func (r *Report) Generate(data *models.AssessmentData) error {
 		return fmt.Errorf("failed to generate table of contents: %v", err)
 	}
 
-	if err := r.generateDashboard(data); err != nil {
+	findings := rules.Evaluate(data)
+	if err := r.generateDashboard(data, findings); err != nil {
 		return fmt.Errorf("failed to generate dashboard: %v", err)
 	}
-	if err := r.generateNodes(data.ClusterInfo.Nodes); err != nil {
-		return fmt.Errorf("failed to generate nodes: %v", err)
-	}
-	if err := r.generateNamespaces(data.ClusterInfo.Namespaces); err != nil {
-		return fmt.Errorf("failed to generate namespaces: %v", err)
-	}
-	if err := r.generatePods(data.Workloads); err != nil {
-		return fmt.Errorf("failed to generate pods: %v", err)
-	}
-	if err := r.generateDeployments(data.Workloads.Deployments); err != nil {
-		return fmt.Errorf("failed to generate deployments: %v", err)
-	}
-	if err := r.generateStatefulSets(data.Workloads.StatefulSets); err != nil {
-		return fmt.Errorf("failed to generate stateful sets: %v", err)
-	}
-	if err := r.generateDaemonSets(data.Workloads.DaemonSets); err != nil {
-		return fmt.Errorf("failed to generate daemon sets: %v", err)
-	}
-	if err := r.generateServices(data.Network.Services); err != nil {
-		return fmt.Errorf("failed to generate services: %v", err)
-	}
-	if err := r.generateNetworkPolicies(data.Network.NetworkPolicies); err != nil {
-		return fmt.Errorf("failed to generate network policies: %v", err)
-	}
-	if err := r.generateIngresses(data.Network.Ingresses); err != nil {
-		return fmt.Errorf("failed to generate ingresses: %v", err)
-	}
-	if err := r.generateSecrets(data.Secrets.Secrets); err != nil {
-		return fmt.Errorf("failed to generate secrets: %v", err)
-	}
-	if err := r.generateServiceAccounts(data.RBAC.ServiceAccounts); err != nil {
-		return fmt.Errorf("failed to generate service accounts: %v", err)
-	}
-	// Only keep the following calls for RBAC:
-	if err := r.generateRoles(data.RBAC); err != nil {
-		return fmt.Errorf("failed to generate roles: %v", err)
-	}
-	if err := r.generateRoleBindings(data.RBAC); err != nil {
-		return fmt.Errorf("failed to generate role bindings: %v", err)
-	}
-	if err := r.generateClusterRoles(data.RBAC); err != nil {
-		return fmt.Errorf("failed to generate cluster roles: %v", err)
-	}
-	if err := r.generateClusterRoleBindings(data.RBAC); err != nil {
-		return fmt.Errorf("failed to generate cluster role bindings: %v", err)
+	tables := reportTables(data, findings)
+	for _, sheet := range sheets[2:] {
+		if err := r.writeTables(sheet, tables); err != nil {
+			return fmt.Errorf("failed to generate %s: %v", strings.ToLower(sheet), err)
+		}
 	}
 
 	// Auto-fit columns in all sheets
@@ -452,22 +463,56 @@ func (r *Report) Generate(data *models.AssessmentData) error {
 		}
 	}
 
-	// Save the file
-	if err := r.excel.SaveAs(r.filePath); err != nil {
-		return fmt.Errorf("failed to save excel file: %v", err)
-	}
-
 	return nil
 }
 
+// reportTables builds the tables of every sheet after the Dashboard, in
+// sheet order
+func reportTables(data *models.AssessmentData, findings []rules.Finding) []Table {
+	escalation := rules.NewEscalationAnalysis(data)
+	consumers := graph.NewConsumers(data)
+	return []Table{
+		findingsTable(findings),
+		nodesTable(data.ClusterInfo.Nodes),
+		hostPortsTable(data.Workloads.Pods),
+		namespacesTable(data.ClusterInfo.Namespaces),
+		podsTable(data.Workloads, graph.NewExposure(data), escalation),
+		volumesTable(data.Workloads.Pods),
+		deploymentsTable(data.Workloads.Deployments, escalation),
+		statefulSetsTable(data.Workloads.StatefulSets, escalation),
+		daemonSetsTable(data.Workloads.DaemonSets, escalation),
+		jobsTable(data.Workloads.Jobs, escalation),
+		cronJobsTable(data.Workloads.CronJobs, escalation),
+		replicaSetsTable(data.Workloads.ReplicaSets, escalation),
+		replicationControllersTable(data.Workloads.ReplicationControllers, escalation),
+		servicesTable(data.Network.Services),
+		networkPoliciesTable(data.Network.NetworkPolicies),
+		ingressesTable(data.Network.Ingresses),
+		secretsTable(data.Secrets.Secrets, consumers, rules.ReferenceTime(data)),
+		configMapsTable(data.ConfigMaps.ConfigMaps, consumers),
+		serviceAccountsTable(data.RBAC.ServiceAccounts),
+		rolesTable(data.RBAC),
+		roleBindingsTable(data.RBAC),
+		clusterRolesTable(data.RBAC),
+		clusterRoleBindingsTable(data.RBAC),
+		effectivePermissionsTable(data, escalation),
+		escalationPathsTable(escalation),
+		coverageTable(data.Coverage),
+		collectionErrorsTable(data.Coverage),
+	}
+}
+
 func (r *Report) generateTableOfContents(_ []string) error {
 	sheet := "Contents"
-	// Insert logo image at the top (cell A1) using file-based approach
-	logoPath := filepath.Join("pkg", "excel", "logo.png")
-	if err := r.excel.AddPicture(sheet, "A1", logoPath, &excelize.GraphicOptions{
-		OffsetX: 0, OffsetY: 0, ScaleX: 0.28, ScaleY: 0.79,
+	// Insert logo image at the top (cell A1)
+	if err := r.excel.AddPictureFromBytes(sheet, "A1", &excelize.Picture{
+		Extension: ".png",
+		File:      assets.Logo,
+		Format: &excelize.GraphicOptions{
+			OffsetX: 0, OffsetY: 0, ScaleX: 0.28, ScaleY: 0.79,
+		},
 	}); err != nil {
-		fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: could not insert logo from %s: %v\n", logoPath, err)
+		fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: could not insert logo: %v\n", err)
 	}
 
 	// Title
@@ -485,21 +530,31 @@ func (r *Report) generateTableOfContents(_ []string) error {
 		target string
 	}{
 		{"Dashboard", "Dashboard"},
+		{"Findings", "Findings"},
 		{"Nodes", "Nodes"},
 		{"Namespaces", "Namespaces"},
 		{"Pods", "Pods"},
+		{"Volumes", "Volumes"},
 		{"Deployments", "Deployments"},
 		{"StatefulSets", "StatefulSets"},
 		{"DaemonSets", "DaemonSets"},
+		{"Jobs", "Jobs"},
+		{"CronJobs", "CronJobs"},
+		{"ReplicaSets", "ReplicaSets"},
+		{"Replication Controllers", "Replication Controllers"},
 		{"Services", "Services"},
 		{"Network Policies", "Network Policies"},
 		{"Ingresses", "Ingresses"},
 		{"Secrets", "Secrets"},
+		{"ConfigMaps", "ConfigMaps"},
 		{"Service Accounts", "Service Accounts"},
 		{"Roles", "Roles"},
 		{"Role Bindings", "Role Bindings"},
 		{"Cluster Roles", "Cluster Roles"},
 		{"Cluster Role Bindings", "Cluster Role Bindings"},
+		{"Effective Permissions", "Effective Permissions"},
+		{"Escalation Paths", "Escalation Paths"},
+		{"Collection Coverage", "Collection Coverage"},
 	}
 	for i, entry := range toc {
 		cell := fmt.Sprintf("A%d", 12+i)
@@ -512,41 +567,27 @@ func (r *Report) generateTableOfContents(_ []string) error {
 }
 
 // Roles pane
-func (r *Report) generateRoles(rbac models.RBACAssessment) error {
-	sheet := "Roles"
-	headers := []string{"Name", "Namespace", "Created At", "Rules"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-	endCol, _ := excelize.ColumnNumberToName(len(headers))
-	r.excel.AutoFilter(sheet, fmt.Sprintf("A1:%s1", endCol), nil)
-	row := 2
+func rolesTable(rbac models.RBACAssessment) Table {
+	t := Table{Sheet: "Roles", Headers: []string{"Name", "Namespace", "Created At", "Rules"}, filter: true}
 	for _, role := range rbac.Roles {
-		values := []interface{}{
+		t.addRow(
 			role.Name,
 			role.Namespace,
 			role.CreatedAt,
 			FormatRules(role.Rules),
-		}
-		for i, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(i+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
-		row++
+		)
 	}
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 func FormatRules(rules []models.PolicyRule) string {
 	var ruleStrings []string
 	for _, rule := range rules {
+		if len(rule.Resources) == 0 && len(rule.NonResourceURLs) > 0 {
+			ruleStrings = append(ruleStrings, fmt.Sprintf("Non-Resource URLs: [%s]\nVerbs: [%s]",
+				strings.Join(rule.NonResourceURLs, ", "),
+				strings.Join(rule.Verbs, ", ")))
+			continue
+		}
 		ruleStr := fmt.Sprintf("API Groups: [%s]\nResources: [%s]\nVerbs: [%s]",
 			strings.Join(rule.APIGroups, ", "),
 			strings.Join(rule.Resources, ", "),
@@ -560,409 +601,481 @@ func FormatRules(rules []models.PolicyRule) string {
 }
 
 // Role Bindings pane
-func (r *Report) generateRoleBindings(rbac models.RBACAssessment) error {
-	sheet := "Role Bindings"
-	headers := []string{"Name", "Namespace", "Role Ref", "Subjects", "Created At"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-	endCol, _ := excelize.ColumnNumberToName(len(headers))
-	r.excel.AutoFilter(sheet, fmt.Sprintf("A1:%s1", endCol), nil)
-	row := 2
+func roleBindingsTable(rbac models.RBACAssessment) Table {
+	t := Table{Sheet: "Role Bindings", Headers: []string{"Name", "Namespace", "Role Ref", "Subjects", "Created At"}, filter: true}
 	for _, binding := range rbac.RoleBindings {
 		subjects := make([]string, 0)
 		for _, subject := range binding.Subjects {
 			subjects = append(subjects, fmt.Sprintf("%s/%s (%s)", subject.Namespace, subject.Name, subject.Kind))
 		}
-		values := []interface{}{
+		t.addRow(
 			binding.Name,
 			binding.Namespace,
-			binding.RoleRef,
+			binding.RoleRef.String(),
 			strings.Join(subjects, ", "),
 			binding.CreatedAt,
-		}
-		for i, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(i+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
-		row++
+		)
 	}
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
-// Cluster Roles pane
-func (r *Report) generateClusterRoles(rbac models.RBACAssessment) error {
-	sheet := "Cluster Roles"
-	headers := []string{"Name", "Created At", "Rules"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-	endCol, _ := excelize.ColumnNumberToName(len(headers))
-	r.excel.AutoFilter(sheet, fmt.Sprintf("A1:%s1", endCol), nil)
-	row := 2
-	for _, role := range rbac.ClusterRoles {
-		values := []interface{}{
+// Cluster Roles pane. Aggregated roles list the ClusterRoles feeding them;
+// sources other than the Kubernetes defaults are highlighted.
+func clusterRolesTable(assessment models.RBACAssessment) Table {
+	t := Table{Sheet: "Cluster Roles", Headers: []string{"Name", "Created At", "Rules", "Aggregation Rule", "Aggregated From", "Labels"}, filter: true}
+	aggregation := rbac.AggregationSources(assessment.ClusterRoles)
+	for _, role := range assessment.ClusterRoles {
+		sources := aggregation[role.Name]
+		row := t.addRow(
 			role.Name,
 			role.CreatedAt,
 			FormatRules(role.Rules),
+			strings.Join(role.AggregationRule, "\n"),
+			strings.Join(sources, "\n"),
+			formatLabels(role.Labels),
+		)
+		if len(rbac.ThirdPartySources(sources)) > 0 {
+			t.highlight(row, "Aggregated From", LevelWarning)
 		}
-		for i, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(i+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
-		row++
 	}
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
 // Cluster Role Bindings pane
-func (r *Report) generateClusterRoleBindings(rbac models.RBACAssessment) error {
-	sheet := "Cluster Role Bindings"
-	headers := []string{"Name", "Role Ref", "Subjects", "Created At"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-	endCol, _ := excelize.ColumnNumberToName(len(headers))
-	r.excel.AutoFilter(sheet, fmt.Sprintf("A1:%s1", endCol), nil)
-	row := 2
+func clusterRoleBindingsTable(rbac models.RBACAssessment) Table {
+	t := Table{Sheet: "Cluster Role Bindings", Headers: []string{"Name", "Role Ref", "Subjects", "Created At"}, filter: true}
 	for _, binding := range rbac.ClusterRoleBindings {
 		subjects := make([]string, 0)
 		for _, subject := range binding.Subjects {
 			subjects = append(subjects, fmt.Sprintf("%s/%s (%s)", subject.Namespace, subject.Name, subject.Kind))
 		}
-		values := []interface{}{
+		t.addRow(
 			binding.Name,
-			binding.RoleRef,
+			binding.RoleRef.String(),
 			strings.Join(subjects, ", "),
 			binding.CreatedAt,
-		}
-		for i, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(i+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
-		row++
+		)
 	}
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
-// Basic report generation methods for each resource type
-func (r *Report) generateDeployments(deployments []models.DeploymentInfo) error {
-	sheet := "Deployments"
-	headers := []string{"Name", "Namespace", "Replicas", "Update Strategy", "Labels", "Created At"}
-
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-
-	for i, deploy := range deployments {
-		row := i + 2
-		values := []interface{}{
+// Basic report tables for each resource type
+func deploymentsTable(deployments []models.DeploymentInfo, escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "Deployments", Headers: []string{
+		"Name", "Namespace", "Replicas", "Update Strategy",
+		"Service Account", "Auto Mount SA Token", "SA Permissions", "SA Escalation",
+		"Labels", "Created At",
+	}}
+	for _, deploy := range deployments {
+		access := escalation.WorkloadAccess(deploy.Namespace, models.PodTemplateInfo{
+			ServiceAccount:               deploy.ServiceAccount,
+			AutomountServiceAccountToken: deploy.AutomountServiceAccountToken,
+		})
+		permissions, escalations := formatWorkloadAccess(access)
+		row := t.addRow(
 			deploy.Name,
 			deploy.Namespace,
 			deploy.Replicas,
 			deploy.UpdateStrategy,
-			r.formatLabels(deploy.Labels),
+			access.ServiceAccount.Name,
+			access.Automount,
+			permissions,
+			escalations,
+			formatLabels(deploy.Labels),
 			deploy.CreatedAt,
-		}
-
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
+		)
+		t.highlight(row, "SA Escalation", workloadAccessLevel(access))
 	}
-
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
-func (r *Report) generateStatefulSets(statefulSets []models.StatefulSetInfo) error {
-	sheet := "StatefulSets"
-	headers := []string{"Name", "Namespace", "Replicas", "Update Strategy", "Labels", "Created At"}
-
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-
-	for i, sts := range statefulSets {
-		row := i + 2
-		values := []interface{}{
+func statefulSetsTable(statefulSets []models.StatefulSetInfo, escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "StatefulSets", Headers: []string{
+		"Name", "Namespace", "Replicas", "Update Strategy",
+		"Service Account", "Auto Mount SA Token", "SA Permissions", "SA Escalation",
+		"Labels", "Created At",
+	}}
+	for _, sts := range statefulSets {
+		access := escalation.WorkloadAccess(sts.Namespace, models.PodTemplateInfo{
+			ServiceAccount:               sts.ServiceAccount,
+			AutomountServiceAccountToken: sts.AutomountServiceAccountToken,
+		})
+		permissions, escalations := formatWorkloadAccess(access)
+		row := t.addRow(
 			sts.Name,
 			sts.Namespace,
 			sts.Replicas,
 			sts.UpdateStrategy,
-			r.formatLabels(sts.Labels),
+			access.ServiceAccount.Name,
+			access.Automount,
+			permissions,
+			escalations,
+			formatLabels(sts.Labels),
 			sts.CreatedAt,
-		}
-
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
+		)
+		t.highlight(row, "SA Escalation", workloadAccessLevel(access))
 	}
-
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
-func (r *Report) generateDaemonSets(daemonSets []models.DaemonSetInfo) error {
-	sheet := "DaemonSets"
-	headers := []string{"Name", "Namespace", "Update Strategy", "Labels", "Created At"}
-
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-
-	for i, ds := range daemonSets {
-		row := i + 2
-		values := []interface{}{
+func daemonSetsTable(daemonSets []models.DaemonSetInfo, escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "DaemonSets", Headers: []string{
+		"Name", "Namespace", "Update Strategy",
+		"Service Account", "Auto Mount SA Token", "SA Permissions", "SA Escalation",
+		"Labels", "Created At",
+	}}
+	for _, ds := range daemonSets {
+		access := escalation.WorkloadAccess(ds.Namespace, models.PodTemplateInfo{
+			ServiceAccount:               ds.ServiceAccount,
+			AutomountServiceAccountToken: ds.AutomountServiceAccountToken,
+		})
+		permissions, escalations := formatWorkloadAccess(access)
+		row := t.addRow(
 			ds.Name,
 			ds.Namespace,
 			ds.UpdateStrategy,
-			r.formatLabels(ds.Labels),
+			access.ServiceAccount.Name,
+			access.Automount,
+			permissions,
+			escalations,
+			formatLabels(ds.Labels),
 			ds.CreatedAt,
-		}
+		)
+		t.highlight(row, "SA Escalation", workloadAccessLevel(access))
+	}
+	return t
+}
 
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
+func jobsTable(jobs []models.JobInfo, escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "Jobs", Headers: []string{
+		"Name", "Namespace", "Owner", "Completions", "Parallelism", "Backoff Limit",
+		"Active", "Succeeded", "Failed",
+		"Service Account", "Auto Mount SA Token", "SA Permissions", "SA Escalation",
+		"Privileged", "Host Network", "Host PID", "Host IPC",
+		"Container Images", "Capabilities", "Labels", "Created At",
+	}}
+	for _, job := range jobs {
+		privileged, images, capabilities := formatTemplate(job.Template)
+		access := escalation.WorkloadAccess(job.Namespace, job.Template)
+		permissions, escalations := formatWorkloadAccess(access)
+		row := t.addRow(
+			job.Name,
+			job.Namespace,
+			job.Owner,
+			job.Completions,
+			job.Parallelism,
+			job.BackoffLimit,
+			job.Active,
+			job.Succeeded,
+			job.Failed,
+			job.Template.ServiceAccount,
+			access.Automount,
+			permissions,
+			escalations,
+			privileged,
+			job.Template.SecurityContext.HostNetwork,
+			job.Template.SecurityContext.HostPID,
+			job.Template.SecurityContext.HostIPC,
+			images,
+			capabilities,
+			formatLabels(job.Labels),
+			job.CreatedAt,
+		)
+		t.highlight(row, "SA Escalation", workloadAccessLevel(access))
+	}
+	return t
+}
+
+func cronJobsTable(cronJobs []models.CronJobInfo, escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "CronJobs", Headers: []string{
+		"Name", "Namespace", "Schedule", "Suspend", "Concurrency Policy", "Last Schedule Time",
+		"Service Account", "Auto Mount SA Token", "SA Permissions", "SA Escalation", "Privileged", "Host Network", "Host PID", "Host IPC",
+		"Container Images", "Capabilities", "Labels", "Created At",
+	}}
+	for _, cj := range cronJobs {
+		privileged, images, capabilities := formatTemplate(cj.Template)
+		access := escalation.WorkloadAccess(cj.Namespace, cj.Template)
+		permissions, escalations := formatWorkloadAccess(access)
+		lastSchedule := cj.LastScheduleTime
+		if lastSchedule == "" {
+			lastSchedule = "Never"
 		}
-	}
+		row := t.addRow(
+			cj.Name,
+			cj.Namespace,
+			cj.Schedule,
+			cj.Suspend,
+			cj.ConcurrencyPolicy,
+			lastSchedule,
+			cj.Template.ServiceAccount,
+			access.Automount,
+			permissions,
+			escalations,
+			privileged,
+			cj.Template.SecurityContext.HostNetwork,
+			cj.Template.SecurityContext.HostPID,
+			cj.Template.SecurityContext.HostIPC,
+			images,
+			capabilities,
+			formatLabels(cj.Labels),
+			cj.CreatedAt,
+		)
+		t.highlight(row, "SA Escalation", workloadAccessLevel(access))
+	}
+	return t
+}
 
-	r.autoFitColumns(sheet)
-	return nil
+func replicaSetsTable(replicaSets []models.ReplicaSetInfo, escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "ReplicaSets", Headers: []string{
+		"Name", "Namespace", "Owner", "Replicas", "Ready Replicas",
+		"Service Account", "Auto Mount SA Token", "SA Permissions", "SA Escalation",
+		"Labels", "Created At",
+	}}
+	for _, rs := range replicaSets {
+		access := escalation.WorkloadAccess(rs.Namespace, models.PodTemplateInfo{
+			ServiceAccount:               rs.ServiceAccount,
+			AutomountServiceAccountToken: rs.AutomountServiceAccountToken,
+		})
+		permissions, escalations := formatWorkloadAccess(access)
+		row := t.addRow(
+			rs.Name,
+			rs.Namespace,
+			rs.Owner,
+			rs.Replicas,
+			rs.ReadyReplicas,
+			access.ServiceAccount.Name,
+			access.Automount,
+			permissions,
+			escalations,
+			formatLabels(rs.Labels),
+			rs.CreatedAt,
+		)
+		t.highlight(row, "SA Escalation", workloadAccessLevel(access))
+	}
+	return t
 }
 
-func (r *Report) generateServices(services []models.ServiceInfo) error {
-	sheet := "Services"
-	headers := []string{"Name", "Namespace", "Type", "Cluster IP", "External IP", "Ports", "Labels", "Created At"}
+func replicationControllersTable(controllers []models.ReplicationControllerInfo, escalation *rules.EscalationAnalysis) Table {
+	t := Table{Sheet: "Replication Controllers", Headers: []string{
+		"Name", "Namespace", "Replicas", "Ready Replicas",
+		"Service Account", "Auto Mount SA Token", "SA Permissions", "SA Escalation",
+		"Labels", "Created At",
+	}}
+	for _, rc := range controllers {
+		access := escalation.WorkloadAccess(rc.Namespace, models.PodTemplateInfo{
+			ServiceAccount:               rc.ServiceAccount,
+			AutomountServiceAccountToken: rc.AutomountServiceAccountToken,
+		})
+		permissions, escalations := formatWorkloadAccess(access)
+		row := t.addRow(
+			rc.Name,
+			rc.Namespace,
+			rc.Replicas,
+			rc.ReadyReplicas,
+			access.ServiceAccount.Name,
+			access.Automount,
+			permissions,
+			escalations,
+			formatLabels(rc.Labels),
+			rc.CreatedAt,
+		)
+		t.highlight(row, "SA Escalation", workloadAccessLevel(access))
+	}
+	return t
+}
 
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
+// formatTemplate summarizes the containers of a pod template: whether any
+// of them is privileged, their images and their capabilities
+func formatTemplate(template models.PodTemplateInfo) (bool, string, string) {
+	privileged := false
+	images := make([]string, 0)
+	capabilities := make([]string, 0)
+	for _, container := range template.Containers {
+		privileged = privileged || container.SecurityContext.Privileged
+		images = append(images, container.Image)
+		capabilities = append(capabilities, container.SecurityContext.Capabilities...)
+	}
+	if len(capabilities) == 0 {
+		return privileged, strings.Join(images, "\n"), "Default (not restricted)"
+	}
+	return privileged, strings.Join(images, "\n"), strings.Join(capabilities, ", ")
+}
 
-	for i, svc := range services {
-		row := i + 2
-		values := []interface{}{
+func servicesTable(services []models.ServiceInfo) Table {
+	t := Table{Sheet: "Services", Headers: []string{"Name", "Namespace", "Type", "Cluster IP", "External IP", "Ports", "Labels", "Created At"}}
+	for _, svc := range services {
+		t.addRow(
 			svc.Name,
 			svc.Namespace,
 			svc.Type,
 			svc.ClusterIP,
 			strings.Join(svc.ExternalIPs, ", "),
-			r.formatPorts(svc.Ports),
-			r.formatLabels(svc.Labels),
-			svc.CreatedAt, // <-- add missing comma here
-		}
-
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
+			formatPorts(svc.Ports),
+			formatLabels(svc.Labels),
+			svc.CreatedAt,
+		)
 	}
-
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
 // Network Policies pane
-func (r *Report) generateNetworkPolicies(networkPolicies []models.NetworkPolicyInfo) error {
-	sheet := "Network Policies"
-	headers := []string{"Name", "Namespace", "Pod Selector", "Policy Types", "Created At", "Labels"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-	endCol, _ := excelize.ColumnNumberToName(len(headers))
-	r.excel.AutoFilter(sheet, fmt.Sprintf("A1:%s1", endCol), nil)
-	row := 2
+func networkPoliciesTable(networkPolicies []models.NetworkPolicyInfo) Table {
+	t := Table{Sheet: "Network Policies", Headers: []string{"Name", "Namespace", "Pod Selector", "Policy Types", "Created At", "Labels"}, filter: true}
 	for _, policy := range networkPolicies {
-		values := []interface{}{
+		t.addRow(
 			policy.Name,
 			policy.Namespace,
 			policy.PodSelector,
 			strings.Join(policy.PolicyTypes, ", "),
 			policy.CreatedAt,
-			r.formatLabels(policy.Labels),
-		}
-		for i, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(i+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
-		row++
+			formatLabels(policy.Labels),
+		)
 	}
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
 // Service Accounts pane
-func (r *Report) generateServiceAccounts(serviceAccounts []models.ServiceAccountInfo) error {
-	sheet := "Service Accounts" // Ensure this matches the sheet created in Generate
-	// If the sheet does not exist, create it (defensive)
-	if idx, _ := r.excel.GetSheetIndex(sheet); idx == -1 {
-		r.excel.NewSheet(sheet)
-	}
-	headers := []string{"Name", "Namespace", "Secrets", "Image Pull Secrets", "Created At", "Labels"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-	endCol, _ := excelize.ColumnNumberToName(len(headers))
-	r.excel.AutoFilter(sheet, fmt.Sprintf("A1:%s1", endCol), nil)
-	row := 2
+func serviceAccountsTable(serviceAccounts []models.ServiceAccountInfo) Table {
+	t := Table{Sheet: "Service Accounts", Headers: []string{"Name", "Namespace", "Secrets", "Image Pull Secrets", "Auto Mount Token", "Created At", "Labels"}, filter: true}
 	for _, sa := range serviceAccounts {
-		values := []interface{}{
+		automount := "Default (true)"
+		if sa.AutomountServiceAccountToken != nil {
+			automount = fmt.Sprintf("%v", *sa.AutomountServiceAccountToken)
+		}
+		t.addRow(
 			sa.Name,
 			sa.Namespace,
 			strings.Join(sa.Secrets, ", "),
 			strings.Join(sa.ImagePullSecrets, ", "),
+			automount,
 			sa.CreatedAt,
-			r.formatLabels(sa.Labels),
-		}
-		for i, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(i+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
-		row++
+			formatLabels(sa.Labels),
+		)
 	}
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
-func (r *Report) generateIngresses(ingresses []models.IngressInfo) error {
-	sheet := "Ingresses"
-	headers := []string{"Name", "Namespace", "Rules", "Labels", "Created At"}
-
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-
-	for i, ing := range ingresses {
-		row := i + 2
-		values := []interface{}{
+func ingressesTable(ingresses []models.IngressInfo) Table {
+	t := Table{Sheet: "Ingresses", Headers: []string{"Name", "Namespace", "Rules", "Labels", "Created At"}}
+	for _, ing := range ingresses {
+		t.addRow(
 			ing.Name,
 			ing.Namespace,
-			r.formatIngressRules(ing.Rules),
-			r.formatLabels(ing.Labels),
+			formatIngressRules(ing.Rules),
+			formatLabels(ing.Labels),
 			ing.CreatedAt,
-		}
-
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
+		)
 	}
-
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
-func (r *Report) generateSecrets(secrets []models.SecretInfo) error {
-	sheet := "Secrets"
-	headers := []string{"Name", "Namespace", "Type", "Labels", "Created At"}
+func secretsTable(secrets []models.SecretInfo, consumers graph.Consumers, now time.Time) Table {
+	t := Table{Sheet: "Secrets", Headers: []string{
+		"Name", "Namespace", "Type", "Keys",
+		"Subject", "SANs", "Issuer", "Not Before", "Not After", "Key", "Signature", "Self-Signed", "Weak Signature",
+		"Labels", "Created At", "Consumers", "Referenced",
+	}}
+	for _, secret := range secrets {
+		used := make([]string, 0)
+		for _, c := range consumers.Secret(secret.Namespace, secret.Name) {
+			used = append(used, c.String())
+		}
 
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
+		// One line per certificate of the chain, leaf first
+		certs := secret.Certificates
+		lines := func(format func(c models.CertificateInfo) string) string {
+			out := make([]string, 0, len(certs))
+			for _, c := range certs {
+				out = append(out, format(c))
+			}
+			return strings.Join(out, "\n")
+		}
+		expiry := ""
+		weak, selfSigned := false, false
+		for _, c := range certs {
+			if days, ok := rules.DaysUntilExpiry(c, now); ok {
+				switch {
+				case days < 0:
+					expiry = LevelCritical
+				case days <= rules.CertificateExpiryWindows[0] && expiry != LevelCritical:
+					expiry = LevelWarning
+				case days <= rules.CertificateExpiryWindows[len(rules.CertificateExpiryWindows)-1] && expiry == "":
+					expiry = LevelModerate
+				}
+			}
+			weak = weak || c.WeakSignature || rules.WeakKey(c)
+		}
+		if len(certs) > 0 {
+			selfSigned = certs[0].SelfSigned && !certs[0].IsCA
+		}
+		subject := lines(func(c models.CertificateInfo) string { return c.Subject })
+		if secret.CertificateError != "" {
+			subject = strings.TrimSpace(subject + "\n" + secret.CertificateError)
+		}
 
-	for i, secret := range secrets {
-		row := i + 2
-		values := []interface{}{
+		row := t.addRow(
 			secret.Name,
 			secret.Namespace,
 			secret.Type,
-			r.formatLabels(secret.Labels),
+			strings.Join(secret.Keys, "\n"),
+			subject,
+			lines(func(c models.CertificateInfo) string { return strings.Join(c.SANs, ", ") }),
+			lines(func(c models.CertificateInfo) string { return c.Issuer }),
+			lines(func(c models.CertificateInfo) string { return c.NotBefore }),
+			lines(func(c models.CertificateInfo) string { return c.NotAfter }),
+			lines(func(c models.CertificateInfo) string { return fmt.Sprintf("%s %d", c.KeyAlgorithm, c.KeySize) }),
+			lines(func(c models.CertificateInfo) string { return c.SignatureAlgorithm }),
+			lines(func(c models.CertificateInfo) string { return strconv.FormatBool(c.SelfSigned) }),
+			lines(func(c models.CertificateInfo) string { return strconv.FormatBool(c.WeakSignature) }),
+			formatLabels(secret.Labels),
 			secret.CreatedAt,
+			strings.Join(used, "\n"),
+			len(used) > 0,
+		)
+		// Secrets nothing references are candidates for removal
+		if consumers.Unreferenced(secret) {
+			t.highlight(row, "Referenced", LevelModerate)
 		}
-
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
+		t.highlight(row, "Not After", expiry)
+		if weak {
+			t.highlight(row, "Weak Signature", LevelWarning)
+			t.highlight(row, "Key", LevelWarning)
+		}
+		if selfSigned {
+			t.highlight(row, "Self-Signed", LevelModerate)
 		}
 	}
+	return t
+}
 
-	r.autoFitColumns(sheet)
-	return nil
+// configMapsTable lists ConfigMaps by key name and size. Values are never
+// written; suspected credentials are named by key and kind only.
+func configMapsTable(configMaps []models.ConfigMapInfo, consumers graph.Consumers) Table {
+	t := Table{Sheet: "ConfigMaps", Headers: []string{"Name", "Namespace", "Keys", "Size", "Consumers", "Suspected Credentials", "Labels", "Created At"}}
+	for _, cm := range configMaps {
+		used := make([]string, 0)
+		for _, c := range consumers.ConfigMap(cm.Namespace, cm.Name) {
+			used = append(used, c.String())
+		}
+		row := t.addRow(
+			cm.Name,
+			cm.Namespace,
+			strings.Join(cm.Keys, "\n"),
+			cm.Size,
+			strings.Join(used, "\n"),
+			strings.ReplaceAll(rules.FormatSuspectedCredentials(cm.SuspectedCredentials), "; ", "\n"),
+			formatLabels(cm.Labels),
+			cm.CreatedAt,
+		)
+		if len(cm.SuspectedCredentials) > 0 {
+			t.highlight(row, "Suspected Credentials", LevelCritical)
+		}
+	}
+	return t
 }
 
 // Helper methods for formatting
-func (r *Report) formatPorts(ports []models.ServicePort) string {
+func formatPorts(ports []models.ServicePort) string {
 	var portStrings []string
 	for _, port := range ports {
 		portStr := fmt.Sprintf("%d", port.Port)
@@ -977,7 +1090,7 @@ func (r *Report) formatPorts(ports []models.ServicePort) string {
 	return strings.Join(portStrings, "\n")
 }
 
-func (r *Report) formatIngressRules(rules []models.IngressRule) string {
+func formatIngressRules(rules []models.IngressRule) string {
 	var ruleStrings []string
 	for _, rule := range rules {
 		ruleStr := rule.Host + " → "
@@ -991,8 +1104,9 @@ func (r *Report) formatIngressRules(rules []models.IngressRule) string {
 	return strings.Join(ruleStrings, "\n")
 }
 
-func (r *Report) generateDashboard(data *models.AssessmentData) error {
+func (r *Report) generateDashboard(data *models.AssessmentData, findings []rules.Finding) error {
 	sheet := "Dashboard"
+	dashboard := summary.NewDashboard(data, findings)
 	r.excel.SetCellValue(sheet, "A1", "Kubernetes Cluster Configuration Overview")
 	r.excel.MergeCell(sheet, "A1", "C1")
 	r.excel.SetCellStyle(sheet, "A1", "C1", r.titleStyle)
@@ -1002,34 +1116,11 @@ func (r *Report) generateDashboard(data *models.AssessmentData) error {
 	r.excel.MergeCell(sheet, "A3", "C3")
 	r.excel.SetCellStyle(sheet, "A3", "C3", r.sectionStyle)
 
-	// Add key metrics
-	metrics := []struct {
-		label string
-		value interface{}
-	}{
-		{"Kubernetes Version", data.ClusterInfo.Version},
-		{"Total Nodes", data.ClusterInfo.NodeCount},
-		{"Total Namespaces", len(data.ClusterInfo.Namespaces)},
-		{"Total Pods", len(data.Workloads.Pods)},
-		{"Total Deployments", len(data.Workloads.Deployments)},
-		{"Total StatefulSets", len(data.Workloads.StatefulSets)},
-		{"Total DaemonSets", len(data.Workloads.DaemonSets)},
-		{"Total Services", len(data.Network.Services)},
-		{"Total Network Policies", len(data.Network.NetworkPolicies)},
-		{"Total Ingresses", len(data.Network.Ingresses)},
-		{"Total Secrets", len(data.Secrets.Secrets)},
-		{"Total Roles", len(data.RBAC.Roles)},
-		{"Total ClusterRoles", len(data.RBAC.ClusterRoles)},
-		{"Total RoleBindings", len(data.RBAC.RoleBindings)},
-		{"Total ClusterRoleBindings", len(data.RBAC.ClusterRoleBindings)},
-		{"Total ServiceAccounts", len(data.RBAC.ServiceAccounts)},
-	}
-
 	// Add metrics to dashboard
-	for i, metric := range metrics {
+	for i, metric := range dashboard.Overview {
 		row := i + 4
-		r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", row), metric.label)
-		r.excel.SetCellValue(sheet, fmt.Sprintf("B%d", row), metric.value)
+		r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", row), metric.Label)
+		r.excel.SetCellValue(sheet, fmt.Sprintf("B%d", row), metric.Value)
 		// Apply alternating row colors
 		style := r.contentStyle
 		if i%2 == 1 {
@@ -1040,146 +1131,219 @@ func (r *Report) generateDashboard(data *models.AssessmentData) error {
 
 	// --- RBAC Summary Table and Chart ---
 	rbacTableStart := 4
-	rbacLabels := []string{"Roles", "ClusterRoles", "RoleBindings", "ClusterRoleBindings", "ServiceAccounts"}
-	rbacCounts := []int{
-		len(data.RBAC.Roles),
-		len(data.RBAC.ClusterRoles),
-		len(data.RBAC.RoleBindings),
-		len(data.RBAC.ClusterRoleBindings),
-		len(data.RBAC.ServiceAccounts),
-	}
 	r.excel.SetCellValue(sheet, "E3", "RBAC Summary")
 	r.excel.MergeCell(sheet, "E3", "F3")
 	r.excel.SetCellStyle(sheet, "E3", "F3", r.sectionStyle)
-	r.excel.SetCellValue(sheet, "E4", "Type")
-	r.excel.SetCellValue(sheet, "F4", "Count")
-	r.excel.SetCellStyle(sheet, "E4", "E4", r.headerStyle)
-	r.excel.SetCellStyle(sheet, "F4", "F4", r.headerStyle)
-	for i, label := range rbacLabels {
-		row := rbacTableStart + 1 + i
-		r.excel.SetCellValue(sheet, fmt.Sprintf("E%d", row), label)
-		r.excel.SetCellValue(sheet, fmt.Sprintf("F%d", row), rbacCounts[i])
-		style := r.contentStyle
-		if i%2 == 1 {
-			style = r.altRowStyle
-		}
-		r.excel.SetCellStyle(sheet, fmt.Sprintf("E%d", row), fmt.Sprintf("F%d", row), style)
-	}
+	rbacEnd := r.writeMetricTable(sheet, rbacTableStart, dashboard.RBAC)
 	// Insert RBAC chart (beside table)
 	r.excel.AddChart(sheet, "H4", &excelize.Chart{
 		Type: excelize.Col,
 		Series: []excelize.ChartSeries{{
 			Name:       "RBAC Objects",
-			Categories: fmt.Sprintf("%s!$E$5:$E$9", sheet),
-			Values:     fmt.Sprintf("%s!$F$5:$F$9", sheet),
+			Categories: fmt.Sprintf("%s!$E$%d:$E$%d", sheet, rbacTableStart+1, rbacEnd),
+			Values:     fmt.Sprintf("%s!$F$%d:$F$%d", sheet, rbacTableStart+1, rbacEnd),
 		}},
 		Title:  []excelize.RichTextRun{{Text: "RBAC Objects Distribution"}},
 		Legend: excelize.ChartLegend{Position: "top"},
 	})
 
 	// --- Pod Security Summary Table and Chart ---
-	podTableStart := 12
-	podTotal := len(data.Workloads.Pods)
-	podPrivileged := 0
-	podHostNetwork := 0
-	podHostPID := 0
-	podHostIPC := 0
-	podRunAsRoot := 0
-	for _, pod := range data.Workloads.Pods {
-		for _, c := range pod.Containers {
-			if c.SecurityContext.Privileged {
-				podPrivileged++
-				break
-			}
-			if c.SecurityContext.RunAsUser != nil && *c.SecurityContext.RunAsUser == 0 {
-				podRunAsRoot++
-				break
-			}
-		}
-		if pod.SecurityContext.HostNetwork {
-			podHostNetwork++
-		}
-		if pod.SecurityContext.HostPID {
-			podHostPID++
-		}
-		if pod.SecurityContext.HostIPC {
-			podHostIPC++
-		}
-	}
-	podLabels := []string{"Total Pods", "Privileged", "Host Network", "Host PID", "Host IPC", "RunAsRoot"}
-	podCounts := []int{podTotal, podPrivileged, podHostNetwork, podHostPID, podHostIPC, podRunAsRoot}
-	r.excel.SetCellValue(sheet, "E12", "Pod Security Summary")
-	r.excel.MergeCell(sheet, "E12", "F12")
-	r.excel.SetCellStyle(sheet, "E12", "F12", r.sectionStyle)
-	r.excel.SetCellValue(sheet, "E13", "Type")
-	r.excel.SetCellValue(sheet, "F13", "Count")
-	r.excel.SetCellStyle(sheet, "E13", "E13", r.headerStyle)
-	r.excel.SetCellStyle(sheet, "F13", "F13", r.headerStyle)
-	for i, label := range podLabels {
-		row := podTableStart + 2 + i
-		r.excel.SetCellValue(sheet, fmt.Sprintf("E%d", row), label)
-		r.excel.SetCellValue(sheet, fmt.Sprintf("F%d", row), podCounts[i])
-		style := r.contentStyle
-		if i%2 == 1 {
-			style = r.altRowStyle
-		}
-		r.excel.SetCellStyle(sheet, fmt.Sprintf("E%d", row), fmt.Sprintf("F%d", row), style)
-	}
+	podTableStart := rbacEnd + 3
+	r.excel.SetCellValue(sheet, fmt.Sprintf("E%d", podTableStart), "Pod Security Summary")
+	r.excel.MergeCell(sheet, fmt.Sprintf("E%d", podTableStart), fmt.Sprintf("F%d", podTableStart))
+	r.excel.SetCellStyle(sheet, fmt.Sprintf("E%d", podTableStart), fmt.Sprintf("F%d", podTableStart), r.sectionStyle)
+	podEnd := r.writeMetricTable(sheet, podTableStart+1, dashboard.PodSecurity)
 	// Insert Pod Security chart below the table
-	r.excel.AddChart(sheet, "E21", &excelize.Chart{
+	r.excel.AddChart(sheet, fmt.Sprintf("E%d", podEnd+2), &excelize.Chart{
 		Type: excelize.Col,
 		Series: []excelize.ChartSeries{{
 			Name:       "Pod Security",
-			Categories: fmt.Sprintf("%s!$E$15:$E$20", sheet),
-			Values:     fmt.Sprintf("%s!$F$15:$F$20", sheet),
+			Categories: fmt.Sprintf("%s!$E$%d:$E$%d", sheet, podTableStart+2, podEnd),
+			Values:     fmt.Sprintf("%s!$F$%d:$F$%d", sheet, podTableStart+2, podEnd),
 		}},
 		Title:  []excelize.RichTextRun{{Text: "Pod Configurations"}},
 		Legend: excelize.ChartLegend{Position: "top"},
 	})
 
+	// --- Findings Summary Table ---
+	findingsTableStart := 4 + len(dashboard.Overview) + 1
+	r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", findingsTableStart), "Findings Summary")
+	r.excel.MergeCell(sheet, fmt.Sprintf("A%d", findingsTableStart), fmt.Sprintf("B%d", findingsTableStart))
+	r.excel.SetCellStyle(sheet, fmt.Sprintf("A%d", findingsTableStart), fmt.Sprintf("B%d", findingsTableStart), r.sectionStyle)
+	r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", findingsTableStart+1), "Severity")
+	r.excel.SetCellValue(sheet, fmt.Sprintf("B%d", findingsTableStart+1), "Count")
+	r.excel.SetCellStyle(sheet, fmt.Sprintf("A%d", findingsTableStart+1), fmt.Sprintf("B%d", findingsTableStart+1), r.headerStyle)
+	for i, metric := range dashboard.Findings {
+		row := findingsTableStart + 2 + i
+		r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", row), metric.Label)
+		r.excel.SetCellValue(sheet, fmt.Sprintf("B%d", row), metric.Value)
+		r.excel.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row), r.levelStyle(SeverityLevel(rules.Severity(metric.Label))))
+	}
+
+	// --- Certificate Expiry Table ---
+	certTableStart := findingsTableStart + 2 + len(dashboard.Findings) + 1
+	r.writeCertificateExpiry(sheet, certTableStart, dashboard)
+
 	// Auto-fit columns
 	r.autoFitColumns(sheet)
 	return nil
 }
 
-func (r *Report) generatePods(workloads models.WorkloadAssessment) error {
-	sheet := "Pods"
-
-	// Set headers with security configurations
-	headers := []string{
-		"Name", "Namespace", "Node", "Service Account",
-		"Privileged", "Host Network", "Host PID", "Host IPC",
-		"Run As Non Root", "Auto Mount SA Token",
-		"No of Containers", "Container Names", "Container Images", "Capabilities",
-		"RunAsUser", "AllowPrivilegeEscalation", "ReadOnlyRootFilesystem",
-		"Resources", "Sysctls", "Environment Variables",
-		"Created At", "Labels",
+// writeCertificateExpiry writes the expiry counts of TLS certificates at row
+// start, followed by the certificates that expired or expire soon
+func (r *Report) writeCertificateExpiry(sheet string, start int, dashboard summary.Dashboard) {
+	r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", start), "Certificate Expiry")
+	r.excel.MergeCell(sheet, fmt.Sprintf("A%d", start), fmt.Sprintf("B%d", start))
+	r.excel.SetCellStyle(sheet, fmt.Sprintf("A%d", start), fmt.Sprintf("B%d", start), r.sectionStyle)
+	r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", start+1), "Window")
+	r.excel.SetCellValue(sheet, fmt.Sprintf("B%d", start+1), "Count")
+	r.excel.SetCellStyle(sheet, fmt.Sprintf("A%d", start+1), fmt.Sprintf("B%d", start+1), r.headerStyle)
+	for i, metric := range dashboard.Certificates {
+		row := start + 2 + i
+		r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", row), metric.Label)
+		r.excel.SetCellValue(sheet, fmt.Sprintf("B%d", row), metric.Value)
+		style := r.contentStyle
+		if count, _ := metric.Value.(int); count > 0 {
+			style = r.moderateStyle
+			if i == 0 {
+				style = r.criticalStyle
+			}
+		}
+		r.excel.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("B%d", row), style)
 	}
+	if len(dashboard.ExpiringCertificates) == 0 {
+		return
+	}
+
+	listStart := start + 2 + len(dashboard.Certificates) + 1
+	headers := []string{"Secret", "Subject", "Not After", "Days Left"}
 	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		cell, _ := excelize.CoordinatesToCellName(i+1, listStart)
 		r.excel.SetCellValue(sheet, cell, header)
 		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
 	}
+	for i, cert := range dashboard.ExpiringCertificates {
+		row := listStart + 1 + i
+		values := []interface{}{cert.Namespace + "/" + cert.Secret, cert.Subject, cert.NotAfter, cert.DaysLeft}
+		style := r.moderateStyle
+		switch {
+		case cert.DaysLeft < 0:
+			style = r.criticalStyle
+		case cert.DaysLeft <= rules.CertificateExpiryWindows[0]:
+			style = r.warningStyle
+		}
+		for j, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(j+1, row)
+			r.excel.SetCellValue(sheet, cell, value)
+			r.excel.SetCellStyle(sheet, cell, cell, style)
+		}
+	}
+}
+
+// writeMetricTable writes a Type/Count table in columns E and F with its
+// header at headerRow and returns the row of the last metric
+func (r *Report) writeMetricTable(sheet string, headerRow int, metrics []summary.Metric) int {
+	r.excel.SetCellValue(sheet, fmt.Sprintf("E%d", headerRow), "Type")
+	r.excel.SetCellValue(sheet, fmt.Sprintf("F%d", headerRow), "Count")
+	r.excel.SetCellStyle(sheet, fmt.Sprintf("E%d", headerRow), fmt.Sprintf("F%d", headerRow), r.headerStyle)
+	for i, metric := range metrics {
+		row := headerRow + 1 + i
+		r.excel.SetCellValue(sheet, fmt.Sprintf("E%d", row), metric.Label)
+		r.excel.SetCellValue(sheet, fmt.Sprintf("F%d", row), metric.Value)
+		style := r.contentStyle
+		if i%2 == 1 {
+			style = r.altRowStyle
+		}
+		r.excel.SetCellStyle(sheet, fmt.Sprintf("E%d", row), fmt.Sprintf("F%d", row), style)
+	}
+	return headerRow + len(metrics)
+}
+
+func podsTable(workloads models.WorkloadAssessment, exposure graph.Exposure, escalation *rules.EscalationAnalysis) Table {
+	// Headers with security configurations
+	t := Table{Sheet: "Pods", Headers: []string{
+		"Name", "Namespace", "Node", "Service Account",
+		"Privileged", "Host Network", "Host PID", "Host IPC", "Share Process Namespace",
+		"Supplemental Groups", "Runtime Class",
+		"Run As Non Root", "Auto Mount SA Token", "Exposed Via", "SA Permissions", "SA Escalation",
+		"No of Containers", "Container Names", "Container Images", "Ephemeral Containers",
+		"Image Pull Policy", "Ports", "Host Ports", "Probes", "Command", "Args", "Capabilities",
+		"RunAsUser", "AllowPrivilegeEscalation", "ReadOnlyRootFilesystem",
+		"Seccomp Profile", "AppArmor Profile", "SELinux Options", "Proc Mount", "Windows Options",
+		"Resources", "Sysctls", "Environment Variables", "Config References",
+		"Created At", "Labels",
+	}}
 
 	// Add pod data
-	for i, pod := range workloads.Pods {
-		row := i + 2
+	for _, pod := range workloads.Pods {
 		containerNames := make([]string, 0)
 		imageNames := make([]string, 0)
+		ephemeral := make([]string, 0)
+		pullPolicies := make([]string, 0)
+		ports := make([]string, 0)
+		hostPorts := make([]string, 0)
+		probes := make([]string, 0)
+		commands := make([]string, 0)
+		args := make([]string, 0)
 		capabilities := make([]string, 0)
 		resourceInfo := make([]string, 0)
 		envVars := make([]string, 0)
+		configRefs := make([]string, 0)
 		runAsUserList := make([]string, 0)
 		allowPrivilegeEscalationList := make([]string, 0)
 		readOnlyRootFilesystemList := make([]string, 0)
+		seccompList := make([]string, 0)
+		appArmorList := make([]string, 0)
+		seLinuxList := make([]string, 0)
+		procMountList := make([]string, 0)
+		windowsList := make([]string, 0)
 		hasPrivileged := false
 		runAsNonRoot := false
-		automountServiceAccountToken := true // default is true in K8s
+		// Token automount falls back to the service account setting, then true
+		access := escalation.WorkloadAccess(pod.Namespace, pod.PodTemplateInfo)
+		permissions, escalations := formatWorkloadAccess(access)
+		exposed := exposure.Pod(pod)
 
 		// Collect container-level information
 		for _, container := range pod.Containers {
-			containerNames = append(containerNames, container.Name)
+			switch container.Type {
+			case models.ContainerTypeInit, models.ContainerTypeSidecar, models.ContainerTypeEphemeral:
+				containerNames = append(containerNames, fmt.Sprintf("%s (%s)", container.Name, container.Type))
+			default:
+				containerNames = append(containerNames, container.Name)
+			}
 			imageNames = append(imageNames, container.Image)
+			if container.Type == models.ContainerTypeEphemeral {
+				ephemeral = append(ephemeral, container.Name)
+			}
+
+			// Ports, probes and how the container is started
+			pullPolicy := rules.EffectivePullPolicy(container)
+			if container.ImagePullPolicy == "" {
+				pullPolicy += " (default)"
+			}
+			pullPolicies = append(pullPolicies, pullPolicy)
+			for _, p := range container.Ports {
+				ports = append(ports, fmt.Sprintf("%s: %d/%s", container.Name, p.ContainerPort, p.Protocol))
+				if p.HostPort != 0 {
+					hostPorts = append(hostPorts, fmt.Sprintf("%s: %s:%d→%d/%s", container.Name, firstNonEmpty(p.HostIP, "0.0.0.0"), p.HostPort, p.ContainerPort, p.Protocol))
+				}
+			}
+			for _, probe := range []struct{ kind, handler string }{
+				{"liveness", container.Probes.Liveness},
+				{"readiness", container.Probes.Readiness},
+				{"startup", container.Probes.Startup},
+			} {
+				if probe.handler != "" {
+					probes = append(probes, fmt.Sprintf("%s: %s %s", container.Name, probe.kind, probe.handler))
+				}
+			}
+			if len(container.Command) > 0 {
+				commands = append(commands, fmt.Sprintf("%s: %s", container.Name, strings.Join(container.Command, " ")))
+			}
+			if len(container.Args) > 0 {
+				args = append(args, fmt.Sprintf("%s: %s", container.Name, strings.Join(container.Args, " ")))
+			}
 
 			// Collect security context information
 			if len(container.SecurityContext.Capabilities) > 0 {
@@ -1207,6 +1371,21 @@ func (r *Report) generatePods(workloads models.WorkloadAssessment) error {
 			}
 			readOnlyRootFilesystemList = append(readOnlyRootFilesystemList, fmt.Sprintf("%v", container.SecurityContext.ReadOnlyRoot))
 
+			// Profiles and options in effect, inherited from the pod when unset
+			seccompList = append(seccompList, rules.EffectiveSeccompProfile(pod.SecurityContext, container.SecurityContext))
+			appArmor := rules.EffectiveAppArmorProfile(pod.SecurityContext, container.SecurityContext)
+			if appArmor == "" {
+				appArmor = "Runtime default"
+			}
+			appArmorList = append(appArmorList, appArmor)
+			if seLinux := firstNonEmpty(container.SecurityContext.SELinuxOptions, pod.SecurityContext.SELinuxOptions); seLinux != "" {
+				seLinuxList = append(seLinuxList, fmt.Sprintf("%s: %s", container.Name, seLinux))
+			}
+			procMountList = append(procMountList, firstNonEmpty(container.SecurityContext.ProcMount, "Default"))
+			if windows := firstNonEmpty(container.SecurityContext.WindowsOptions, pod.SecurityContext.WindowsOptions); windows != "" {
+				windowsList = append(windowsList, fmt.Sprintf("%s: %s", container.Name, windows))
+			}
+
 			// Format resource information
 			if container.Resources.Limits.CPU != "" {
 				resourceInfo = append(resourceInfo, fmt.Sprintf("%s: CPU limit %s", container.Name, container.Resources.Limits.CPU))
@@ -1221,21 +1400,27 @@ func (r *Report) generatePods(workloads models.WorkloadAssessment) error {
 				resourceInfo = append(resourceInfo, fmt.Sprintf("%s: Memory request %s", container.Name, container.Resources.Requests.Memory))
 			}
 
-			// Collect environment variables (excluding secrets)
+			// Collect environment variable names with the Secret or
+			// ConfigMap they come from; values are never collected
+			sources := make(map[string]string)
+			for _, ref := range container.ConfigReferences {
+				reference := fmt.Sprintf("%s %s", ref.Kind, ref.Name)
+				if ref.Key != "" {
+					reference += "/" + ref.Key
+				}
+				configRefs = append(configRefs, fmt.Sprintf("%s: %s (%s)", container.Name, reference, ref.Via))
+				if strings.HasPrefix(ref.Via, "env ") {
+					sources[strings.TrimPrefix(ref.Via, "env ")] = reference
+				}
+			}
 			for _, env := range container.EnvVars {
-				if !strings.Contains(strings.ToLower(env), "secret") &&
-					!strings.Contains(strings.ToLower(env), "password") &&
-					!strings.Contains(strings.ToLower(env), "key") {
-					envVars = append(envVars, env)
+				if source, ok := sources[env]; ok {
+					env = fmt.Sprintf("%s ← %s", env, source)
 				}
+				envVars = append(envVars, env)
 			}
 		}
 
-		// If pod spec has automountServiceAccountToken set, override default
-		if pod.AutomountServiceAccountToken != nil {
-			automountServiceAccountToken = *pod.AutomountServiceAccountToken
-		}
-
 		// If no capabilities are set, show 'Default (not restricted)'
 		capabilitiesStr := ""
 		if len(capabilities) > 0 {
@@ -1247,7 +1432,15 @@ func (r *Report) generatePods(workloads models.WorkloadAssessment) error {
 		// Number of containers
 		numContainers := len(pod.Containers)
 
-		values := []interface{}{
+		sysctls := make([]string, 0)
+		unsafeSysctls := false
+		for _, sysctl := range pod.SecurityContext.Sysctls {
+			class := rules.ClassifySysctl(sysctl.Name)
+			sysctls = append(sysctls, fmt.Sprintf("%s=%s (%s)", sysctl.Name, sysctl.Value, class))
+			unsafeSysctls = unsafeSysctls || class != rules.SysctlSafe
+		}
+
+		row := t.addRow(
 			pod.Name,
 			pod.Namespace,
 			pod.NodeName,
@@ -1256,49 +1449,78 @@ func (r *Report) generatePods(workloads models.WorkloadAssessment) error {
 			pod.SecurityContext.HostNetwork,
 			pod.SecurityContext.HostPID,
 			pod.SecurityContext.HostIPC,
+			pod.SecurityContext.ShareProcessNamespace,
+			formatInt64s(pod.SecurityContext.SupplementalGroups),
+			pod.SecurityContext.RuntimeClassName,
 			runAsNonRoot,
-			automountServiceAccountToken,
+			access.Automount,
+			strings.Join(exposed, "\n"),
+			permissions,
+			escalations,
 			numContainers,
 			strings.Join(containerNames, ", "),
 			strings.Join(imageNames, "\n"),
+			strings.Join(ephemeral, ", "),
+			strings.Join(pullPolicies, ", "),
+			strings.Join(ports, "\n"),
+			strings.Join(hostPorts, "\n"),
+			strings.Join(probes, "\n"),
+			strings.Join(commands, "\n"),
+			strings.Join(args, "\n"),
 			capabilitiesStr,
 			strings.Join(runAsUserList, ", "),
 			strings.Join(allowPrivilegeEscalationList, ", "),
 			strings.Join(readOnlyRootFilesystemList, ", "),
+			strings.Join(seccompList, ", "),
+			strings.Join(appArmorList, ", "),
+			strings.Join(seLinuxList, "\n"),
+			strings.Join(procMountList, ", "),
+			strings.Join(windowsList, "\n"),
 			strings.Join(resourceInfo, "\n"),
-			"N/A", // sysctls - not directly available in the model
+			strings.Join(sysctls, "\n"),
 			strings.Join(envVars, "\n"),
+			strings.Join(configRefs, "\n"),
 			pod.CreatedAt,
-			r.formatLabels(pod.Labels),
+			formatLabels(pod.Labels),
+		)
+		// Highlight debug shells attached to running pods
+		if len(ephemeral) > 0 {
+			t.highlight(row, "Ephemeral Containers", LevelWarning)
 		}
-
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
+		if len(hostPorts) > 0 {
+			t.highlight(row, "Host Ports", LevelWarning)
+		}
+		if unsafeSysctls {
+			t.highlight(row, "Sysctls", LevelModerate)
 		}
+		t.highlight(row, "SA Escalation", workloadAccessLevel(access))
 	}
+	return t
+}
 
-	r.autoFitColumns(sheet)
-	return nil
+// firstNonEmpty returns the first of the values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
 }
 
-func (r *Report) generateNodes(nodes []models.NodeInfo) error {
-	sheet := "Nodes"
-	headers := []string{"Name", "Version", "Architecture", "OS", "Container Runtime", "CPU", "Memory", "Ready", "Labels"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
+// formatInt64s joins numbers with commas
+func formatInt64s(values []int64) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, strconv.FormatInt(v, 10))
 	}
-	for i, node := range nodes {
-		row := i + 2
-		values := []interface{}{
+	return strings.Join(parts, ", ")
+}
+
+func nodesTable(nodes []models.NodeInfo) Table {
+	t := Table{Sheet: "Nodes", Headers: []string{"Name", "Version", "Architecture", "OS", "Container Runtime", "CPU", "Memory", "Ready", "Labels"}}
+	for _, node := range nodes {
+		t.addRow(
 			node.Name,
 			node.Version,
 			node.Architecture,
@@ -1307,48 +1529,21 @@ func (r *Report) generateNodes(nodes []models.NodeInfo) error {
 			node.CPU,
 			node.Memory,
 			node.Ready,
-			r.formatLabels(node.Labels),
-		}
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
+			formatLabels(node.Labels),
+		)
 	}
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
 
-func (r *Report) generateNamespaces(namespaces []models.NamespaceInfo) error {
-	sheet := "Namespaces"
-	headers := []string{"Name", "Status", "Created At", "Labels"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		r.excel.SetCellValue(sheet, cell, header)
-		r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
-	}
-	for i, ns := range namespaces {
-		row := i + 2
-		values := []interface{}{
+func namespacesTable(namespaces []models.NamespaceInfo) Table {
+	t := Table{Sheet: "Namespaces", Headers: []string{"Name", "Status", "Created At", "Labels"}}
+	for _, ns := range namespaces {
+		t.addRow(
 			ns.Name,
 			ns.Status,
 			ns.CreatedAt,
-			r.formatLabels(ns.Labels),
-		}
-		for j, value := range values {
-			cell, _ := excelize.CoordinatesToCellName(j+1, row)
-			r.excel.SetCellValue(sheet, cell, value)
-			style := r.contentStyle
-			if row%2 == 0 {
-				style = r.altRowStyle
-			}
-			r.excel.SetCellStyle(sheet, cell, cell, style)
-		}
+			formatLabels(ns.Labels),
+		)
 	}
-	r.autoFitColumns(sheet)
-	return nil
+	return t
 }
@@ -0,0 +1,160 @@
+package excel
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kubeRadar/pkg/collector"
+	"kubeRadar/pkg/drift"
+	"kubeRadar/pkg/fixture"
+	"kubeRadar/pkg/models"
+
+	"github.com/xuri/excelize/v2"
+	"k8s.io/client-go/rest"
+)
+
+func collectFixture(t *testing.T, context string) *models.AssessmentData {
+	t.Helper()
+	opts := collector.DefaultOptions()
+	opts.Context = context
+	opts.InspectSecrets = true
+	c := collector.NewCollectorForClients(collector.Clients{Kube: fixture.Clientset()}, &rest.Config{Host: "https://fixture.example.com:6443"}, opts)
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+	data.ClusterInfo.CollectedAt = fixture.CollectedAt.String()
+	return data
+}
+
+// dumpWorkbook renders every sheet of a workbook as tab-separated rows
+func dumpWorkbook(t *testing.T, path string) []byte {
+	t.Helper()
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	defer f.Close()
+
+	var b strings.Builder
+	for _, sheet := range f.GetSheetList() {
+		rows, err := f.GetRows(sheet)
+		if err != nil {
+			t.Fatalf("failed to read sheet %s: %v", sheet, err)
+		}
+		fmt.Fprintf(&b, "== %s ==\n", sheet)
+		for _, row := range rows {
+			b.WriteString(strings.Join(row, "\t"))
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+func TestGenerateGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xlsx")
+	report, err := NewReport(path)
+	if err != nil {
+		t.Fatalf("NewReport failed: %v", err)
+	}
+	if err := report.Generate(collectFixture(t, "fixture")); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	fixture.AssertGolden(t, "testdata/report.golden.txt", dumpWorkbook(t, path))
+}
+
+func TestGenerateOmitsConfigMapValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xlsx")
+	report, err := NewReport(path)
+	if err != nil {
+		t.Fatalf("NewReport failed: %v", err)
+	}
+	if err := report.Generate(collectFixture(t, "fixture")); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	dump := string(dumpWorkbook(t, path))
+	if !strings.Contains(dump, "DATABASE_URL (connection string with password)") {
+		t.Error("credential in ConfigMap api-config not flagged")
+	}
+	for _, value := range fixture.LeakedCredentials {
+		if strings.Contains(dump, value) {
+			t.Errorf("report contains the ConfigMap value %q", value)
+		}
+	}
+}
+
+func TestGenerateCombinedGolden(t *testing.T) {
+	dir := t.TempDir()
+	clusters := make([]ClusterReport, 0)
+	for _, name := range []string{"prod", "staging"} {
+		clusters = append(clusters, ClusterReport{Name: name, Data: collectFixture(t, name)})
+	}
+
+	path := filepath.Join(dir, "combined.xlsx")
+	if err := GenerateCombined(path, clusters); err != nil {
+		t.Fatalf("GenerateCombined failed: %v", err)
+	}
+	fixture.AssertGolden(t, "testdata/combined.golden.txt", dumpWorkbook(t, path))
+
+	// Values keep the type they were written with in the cluster reports
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatalf("failed to open workbook: %v", err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Deployments")
+	if err != nil {
+		t.Fatalf("failed to read Deployments: %v", err)
+	}
+	for col, want := range map[string]excelize.CellType{"Replicas": excelize.CellTypeUnset, "Auto Mount SA Token": excelize.CellTypeBool, "Name": excelize.CellTypeSharedString} {
+		for i, header := range rows[0] {
+			if header != col {
+				continue
+			}
+			cell, _ := excelize.CoordinatesToCellName(i+1, 2)
+			if got, _ := f.GetCellType("Deployments", cell); got != want {
+				t.Errorf("Deployments %s has cell type %v, want %v", col, got, want)
+			}
+		}
+	}
+
+	// Highlights use the styles of the combined workbook
+	if severity, _ := f.GetCellValue("Findings", "C2"); severity != "Critical" {
+		t.Fatalf("Findings C2 = %q, want a critical finding", severity)
+	}
+	id, err := f.GetCellStyle("Findings", "C2")
+	if err != nil {
+		t.Fatalf("failed to read style of Findings C2: %v", err)
+	}
+	style, err := f.GetStyle(id)
+	if err != nil {
+		t.Fatalf("failed to read style %d: %v", id, err)
+	}
+	if len(style.Fill.Color) == 0 || style.Fill.Color[0] != "FFCCCC" {
+		t.Errorf("critical finding has fill %v, want FFCCCC", style.Fill.Color)
+	}
+}
+
+func TestGenerateDriftGolden(t *testing.T) {
+	old := collectFixture(t, "fixture")
+	new := collectFixture(t, "fixture")
+	new.Network.NetworkPolicies = nil
+	new.Workloads.Deployments[0].Replicas = 5
+	new.RBAC.ClusterRoleBindings = append(new.RBAC.ClusterRoleBindings, models.BindingInfo{
+		Name:     "contractor-admin",
+		RoleRef:  models.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		Subjects: []models.Subject{{Kind: "User", Name: "bob@example.com"}},
+	})
+
+	path := filepath.Join(t.TempDir(), "drift.xlsx")
+	report, err := NewReport(path)
+	if err != nil {
+		t.Fatalf("NewReport failed: %v", err)
+	}
+	if err := report.GenerateDrift(drift.Compare(old, new)); err != nil {
+		t.Fatalf("GenerateDrift failed: %v", err)
+	}
+	fixture.AssertGolden(t, "testdata/drift.golden.txt", dumpWorkbook(t, path))
+}
@@ -0,0 +1,162 @@
+package excel
+
+import (
+	"fmt"
+
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rules"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Highlight levels of table cells, matching the workbook fill colors
+const (
+	LevelCritical = "critical"
+	LevelWarning  = "warning"
+	LevelModerate = "moderate"
+	LevelLow      = "low"
+	LevelGood     = "good"
+)
+
+// Cell is a table cell with its highlight level, empty for plain cells.
+// Value keeps its type, so the workbook stores numbers and booleans as such.
+// | Value | Level |
+type Cell struct {
+	Value interface{} // nil leaves the cell empty
+	Level string
+}
+
+// String formats the value the way the workbook displays it
+func (c Cell) String() string {
+	switch v := c.Value.(type) {
+	case nil:
+		return ""
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	return fmt.Sprint(c.Value)
+}
+
+// Table is a header and its rows on a report sheet
+// | Sheet | Title | Headers | Rows |
+type Table struct {
+	Sheet   string
+	Title   string // section title above the header, empty for the main table
+	Headers []string
+	Rows    [][]Cell
+	filter  bool // whether the workbook adds an auto filter to the header
+}
+
+// addRow appends a row of plain cells and returns it, so single cells can be
+// highlighted afterwards
+func (t *Table) addRow(values ...interface{}) []Cell {
+	row := make([]Cell, len(values))
+	for i, value := range values {
+		row[i].Value = value
+	}
+	t.Rows = append(t.Rows, row)
+	return row
+}
+
+// highlight sets the level of the cell below header, unless level is empty
+func (t *Table) highlight(row []Cell, header, level string) {
+	if level == "" {
+		return
+	}
+	for i, h := range t.Headers {
+		if h == header {
+			row[i].Level = level
+		}
+	}
+}
+
+// messageRow returns a row stating a message instead of data, highlighted
+// across all columns
+func messageRow(columns int, message, level string) []Cell {
+	row := make([]Cell, columns)
+	for i := range row {
+		row[i].Level = level
+	}
+	row[0].Value = message
+	return row
+}
+
+// Tables returns the tables of every sheet of the report except Contents and
+// Dashboard. They are the rows the workbook is written from, so other
+// renderers present exactly the same data.
+func Tables(data *models.AssessmentData) []Table {
+	return reportTables(data, rules.Evaluate(data))
+}
+
+// levelStyle returns the cell style of a highlight level, 0 for plain cells
+func (r *Report) levelStyle(level string) int {
+	switch level {
+	case LevelCritical:
+		return r.criticalStyle
+	case LevelWarning:
+		return r.warningStyle
+	case LevelModerate:
+		return r.moderateStyle
+	case LevelLow:
+		return r.lowStyle
+	case LevelGood:
+		return r.goodStyle
+	}
+	return 0
+}
+
+// writeTables writes the tables belonging to a sheet one below the other,
+// separated by a blank row. Plain cells alternate between the content and
+// alternating row styles.
+func (r *Report) writeTables(sheet string, tables []Table) error {
+	row := 1
+	for _, t := range tables {
+		if t.Sheet != sheet {
+			continue
+		}
+		if row > 1 {
+			row++ // blank row between tables
+		}
+		endCol, err := excelize.ColumnNumberToName(len(t.Headers))
+		if err != nil {
+			return err
+		}
+		if t.Title != "" {
+			r.excel.SetCellValue(sheet, fmt.Sprintf("A%d", row), t.Title)
+			r.excel.MergeCell(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("%s%d", endCol, row))
+			r.excel.SetCellStyle(sheet, fmt.Sprintf("A%d", row), fmt.Sprintf("%s%d", endCol, row), r.sectionStyle)
+			row++
+		}
+		for i, header := range t.Headers {
+			cell, _ := excelize.CoordinatesToCellName(i+1, row)
+			r.excel.SetCellValue(sheet, cell, header)
+			r.excel.SetCellStyle(sheet, cell, cell, r.headerStyle)
+		}
+		if t.filter {
+			r.excel.AutoFilter(sheet, fmt.Sprintf("A%d:%s%d", row, endCol, row), nil)
+		}
+		row++
+
+		for _, cells := range t.Rows {
+			for j, c := range cells {
+				cell, _ := excelize.CoordinatesToCellName(j+1, row)
+				if c.Value != nil {
+					r.excel.SetCellValue(sheet, cell, c.Value)
+				}
+				style := r.levelStyle(c.Level)
+				if style == 0 {
+					style = r.contentStyle
+					if row%2 == 0 {
+						style = r.altRowStyle
+					}
+				}
+				r.excel.SetCellStyle(sheet, cell, cell, style)
+			}
+			row++
+		}
+	}
+	return r.autoFitColumns(sheet)
+}
@@ -0,0 +1,82 @@
+package excel
+
+import (
+	"strings"
+	"time"
+
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rules"
+)
+
+// volumeRisk describes why a volume deserves attention and returns the
+// highlight level matching the most serious reason
+func volumeRisk(v models.VolumeInfo) (string, string) {
+	risks := make([]string, 0)
+	level := ""
+	if v.Type == "hostPath" && rules.SensitiveHostPath(v.Source) {
+		risks = append(risks, "Sensitive host path")
+		level = LevelCritical
+	}
+	if rules.WritableHostPath(v) {
+		risks = append(risks, "Writable host path")
+		if level == "" {
+			level = LevelWarning
+		}
+	}
+	if v.ServiceAccountToken {
+		risks = append(risks, "Service account token")
+		if level == "" {
+			level = LevelModerate
+		}
+	}
+	return strings.Join(risks, "; "), level
+}
+
+// Volumes pane
+func volumesTable(pods []models.PodInfo) Table {
+	t := Table{Sheet: "Volumes", Headers: []string{
+		"Pod", "Namespace", "Volume", "Type", "Source",
+		"Container", "Mount Path", "Sub Path", "Read Only",
+		"Token Audience", "Token Expiry", "Risk",
+	}, filter: true}
+	for _, pod := range pods {
+		for _, v := range pod.Volumes {
+			risk, riskLevel := volumeRisk(v)
+			audience, expiry := "", ""
+			if v.ServiceAccountToken {
+				audience = v.TokenAudience
+				if audience == "" {
+					audience = "API server"
+				}
+				expiry = "Default (1h)"
+				if v.TokenExpirationSeconds != nil {
+					expiry = (time.Duration(*v.TokenExpirationSeconds) * time.Second).String()
+				}
+			}
+
+			// One row per mount, or a single row for volumes nobody mounts
+			mounts := v.Mounts
+			if len(mounts) == 0 {
+				mounts = []models.VolumeMountInfo{{}}
+			}
+			for _, m := range mounts {
+				row := t.addRow(
+					pod.Name,
+					pod.Namespace,
+					v.Name,
+					v.Type,
+					v.Source,
+					m.Container,
+					m.MountPath,
+					m.SubPath,
+					v.ReadOnly || m.ReadOnly,
+					audience,
+					expiry,
+					risk,
+				)
+				t.highlight(row, "Risk", riskLevel)
+			}
+		}
+	}
+	return t
+}
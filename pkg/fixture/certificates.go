@@ -0,0 +1,86 @@
+package fixture
+
+// Certificates of the fixture TLS Secrets. Their validity is chosen relative
+// to CollectedAt: the api-tls leaf expires within 30 days, the webhook
+// certificate within 90 days, and the legacy one has already expired.
+const (
+	// apiTLSChain is a 2048-bit RSA leaf for pay.example.com followed by the
+	// Example Issuing CA that signed it
+	apiTLSChain = `-----BEGIN CERTIFICATE-----
+MIIDPzCCAiegAwIBAgIBAjANBgkqhkiG9w0BAQsFADA0MRUwEwYDVQQKEwxFeGFt
+cGxlIENvcnAxGzAZBgNVBAMTEkV4YW1wbGUgSXNzdWluZyBDQTAeFw0yNDAzMjIw
+MDAwMDBaFw0yNDA2MjAwMDAwMDBaMBoxGDAWBgNVBAMTD3BheS5leGFtcGxlLmNv
+bTCCASIwDQYJKoZIhvcNAQEBBQADggEPADCCAQoCggEBALee/5mtNmY217bKiqLo
+2H+AlOanVKifBtnBzld8q6byY0DjcXSz/faPb96IixmXXokCT3skEvHQKKCV5lWV
+UeANyEy9sXKtTQucjJaVtY0S7SDi/6tXNLk7wdAOjLTCkPTvqI/1tNStDgw5MvMJ
+Ib90w4fImMTGXtyxencaRRj9YegWdrQzB/4hsZuw+/B52MU6K0yRiNYU0/gVxcCx
+J1ZhlXwGHzpYXN6VAah4xpZnxNjmsHBznKZLZi1bgBa/hj6biW0x0gULLJxjAYXn
+CvbAzYgz6EbMW5IX2GvtFpbbOkbSNY6OBOy5f47bNVnxXpvz1IphgyyHvjn6KpGl
+X9kCAwEAAaN2MHQwDgYDVR0PAQH/BAQDAgeAMBMGA1UdJQQMMAoGCCsGAQUFBwMB
+MB8GA1UdIwQYMBaAFHscASr0BAqE/HF2abcZDlf424YuMCwGA1UdEQQlMCOCD3Bh
+eS5leGFtcGxlLmNvbYIQYXBpLnBheW1lbnRzLnN2YzANBgkqhkiG9w0BAQsFAAOC
+AQEAOpnS9yJpZ1H3N3/vZbpr1wunVKiRsH7KwlytoptQAo1zZoK1ObalB+R4BBjb
+BGnMAgUB0IKnwgsXvRH4s8eH1u2iLaS61t0SvWyycarT15ynXxvKw45reJV004DW
+FCzGR/dJtTa46guU/swpR6fq8c4ry9bcxIcTcSbnBOp5yEIN1R8MVIDiNr9MnzpU
+BIJzp06/AEJJ8uH6rqdZZAejYOwvQ3E7S9O3qZDHFXJvo0kD2LxWe7dIo6OIIN8D
+7RCu7XpYwiExWL3CerNk2Rbp6SNJ32aSJkxscefOjEWmZOtEyPl1AUQIwLTL1uoE
+GQDbdLN2oqqXJOxKDutRoTpXgQ==
+-----END CERTIFICATE-----
+-----BEGIN CERTIFICATE-----
+MIIDJTCCAg2gAwIBAgIBATANBgkqhkiG9w0BAQsFADA0MRUwEwYDVQQKEwxFeGFt
+cGxlIENvcnAxGzAZBgNVBAMTEkV4YW1wbGUgSXNzdWluZyBDQTAeFw0yMzAxMDEw
+MDAwMDBaFw0zMzAxMDEwMDAwMDBaMDQxFTATBgNVBAoTDEV4YW1wbGUgQ29ycDEb
+MBkGA1UEAxMSRXhhbXBsZSBJc3N1aW5nIENBMIIBIjANBgkqhkiG9w0BAQEFAAOC
+AQ8AMIIBCgKCAQEA0ysIQ4JtFL1q+D3Z/sXfW9ZzW1Sf1W+uEBArM0wphSd2mPsV
+qnvVuNcuvLwjfMFlaiXrxxZHM6+ICazx1s7Aubezrx1323qkwrZuMwmJgIwkWhQc
+6Net6Q5XrLs09r1wCvrOGJQPqAq6Fbcmoyhi173++0GCgqaPz630qU3BAgRYOnjJ
+Sy5nOng0pWCe/Yvgy/+WHYi5n0fFu32+VnGky4wvb01PNT6YVq7LI/wI2PMdcYQo
+4WU7LdfxJf+VGTo4XTRtCWmioWT+LKY7rdmijS5EPMLROEBJV72ibFi8n0GxmUhs
+5o63HRcXEjG6HApkvcQd5AyrFOftZe/6YyCNIQIDAQABo0IwQDAOBgNVHQ8BAf8E
+BAMCAgQwDwYDVR0TAQH/BAUwAwEB/zAdBgNVHQ4EFgQUexwBKvQECoT8cXZptxkO
+V/jbhi4wDQYJKoZIhvcNAQELBQADggEBAFO0ZPQ+dcXW/J5fMSxEoxW2pKEaz1cl
+NngqyjlU38lmY2e6KWV4wX7+/8/969GabihmEwnTZv3jak3nwBQpIbKPA1Cy8qmX
+GMp4ntVZG3+UtDN31fw4xeH3FaoI54JyeVXVc8299HkTaYrEi/CCj920+vdDxTGk
+PM7InWN0iiomEHjb1jKQw3K8yF2U7e/60dS0ItWtVBBWug1EGTFKwE7RJKxflJf8
+NCSCcI2jQeJ8pw41xo1fD3fzZmFeXJkCGADIE6i2dDxrswSIA+963C9cpeBZrDx5
+EycdbT+KBYRjrlTlHJbniJRQZV5iLAVNw9/v1sMddEeeKOb8C0RgtDU=
+-----END CERTIFICATE-----
+`
+
+	// webhookCertificate is an ECDSA P-256 certificate for a webhook Service,
+	// issued by the Example Issuing CA
+	webhookCertificate = `-----BEGIN CERTIFICATE-----
+MIICszCCAZugAwIBAgIBAzANBgkqhkiG9w0BAQsFADA0MRUwEwYDVQQKEwxFeGFt
+cGxlIENvcnAxGzAZBgNVBAMTEkV4YW1wbGUgSXNzdWluZyBDQTAeFw0yNDA1MjIw
+MDAwMDBaFw0yNDA4MjAwMDAwMDBaMCoxKDAmBgNVBAMTH21ldHJpY3Mtd2ViaG9v
+ay5rdWJlLXN5c3RlbS5zdmMwWTATBgcqhkjOPQIBBggqhkjOPQMBBwNCAAQI9ovr
+R8MO2t39/vlLkvJ7qDRLBJurkBG55KOJKjE0E05C2H+neyHBUj811U8KTlB26+YB
+zJ/f5b4e0MdKIKaZo4GkMIGhMA4GA1UdDwEB/wQEAwIHgDATBgNVHSUEDDAKBggr
+BgEFBQcDATAfBgNVHSMEGDAWgBR7HAEq9AQKhPxxdmm3GQ5X+NuGLjBZBgNVHREE
+UjBQgh9tZXRyaWNzLXdlYmhvb2sua3ViZS1zeXN0ZW0uc3Zjgi1tZXRyaWNzLXdl
+Ymhvb2sua3ViZS1zeXN0ZW0uc3ZjLmNsdXN0ZXIubG9jYWwwDQYJKoZIhvcNAQEL
+BQADggEBAIluoJTkNqxpHxy7at+4FSw8tP7njDw5ziLSp9eKgUZ+BTioWtlrKhBA
+WwIImwKim8Bi227HvVGP0uM/TZsW1Ieq4iNgD/9+8MbSCmN2wscxFDqnGosoGe6N
+6H9i0L54q+2pFt/K8M5muOe4rDogF8gVf99jUvQZQLBm3nmgEpON9fmrQW8t5B5p
+VscF1OZ25KugIk0zI0qm5WIEDFIJZa17iqFGcGA54I0sO+wgNAE+9galgbSWYxBS
+1MiIy3cgx29L/qHz2kBgaIX9B4IZIpyx0nMWkIvA5pKKUogU3orJ0H+KRwTZnNI7
+SneROBlTvfpj+ChM/D+wtdEUN5xj35Q=
+-----END CERTIFICATE-----
+`
+
+	// legacyCertificate is a self-signed certificate with a 1024-bit RSA key
+	// and a SHA-1 signature
+	legacyCertificate = `-----BEGIN CERTIFICATE-----
+MIIB0TCCATqgAwIBAgIBBDANBgkqhkiG9w0BAQUFADAdMRswGQYDVQQDExJsZWdh
+Y3kuZXhhbXBsZS5jb20wHhcNMjEwNTAxMDAwMDAwWhcNMjQwNTAxMDAwMDAwWjAd
+MRswGQYDVQQDExJsZWdhY3kuZXhhbXBsZS5jb20wgZ8wDQYJKoZIhvcNAQEBBQAD
+gY0AMIGJAoGBAO6WkReUd6T02fXFit1i1zt+65nSJPIx1cJ+ERP5UqjitKEMZLqf
+JJwgRduCGVHKxOdm7JCK84fJ9eotYW4L6h3FClFTDdcK6VUqh/c1dqMSFkR6C9Z/
+salusvJK3TKaD4Khc3giprjWl0D9gXR2mZRM0oXCgnI+ZM1Stm5yYB4RAgMBAAGj
+ITAfMB0GA1UdEQQWMBSCEmxlZ2FjeS5leGFtcGxlLmNvbTANBgkqhkiG9w0BAQUF
+AAOBgQCBY6e3SvL6YTUBO05pVsIter+lVq9MS573UTf8MqfXlV4u8QzS58fpMEwf
+ffNqjgiEs6a26oNQeYbM+mrEB3PbygQMSrSCD7WDs33r/SBFksB6XWC64fbpu3t2
+BuyYMFGGLDee846jKLDYywLmdEV/SYrQUy6Ir+g2eLbXkZuLbQ==
+-----END CERTIFICATE-----
+`
+)
@@ -0,0 +1,147 @@
+// Package graph relates the collected objects to each other, e.g. which pods
+// and service accounts consume a Secret.
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"kubeRadar/pkg/models"
+)
+
+// Consumer is an object that reads a Secret or ConfigMap
+// | Kind | Namespace | Name | Via |
+type Consumer struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Via       string // e.g. container api: env DB_PASSWORD
+}
+
+func (c Consumer) String() string {
+	return fmt.Sprintf("%s %s (%s)", c.Kind, c.Name, c.Via)
+}
+
+type key struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// Consumers is a reverse index from Secrets and ConfigMaps to the objects
+// consuming them
+type Consumers map[key][]Consumer
+
+// NewConsumers indexes the Secrets and ConfigMaps referenced by pods, the pod
+// templates of workload controllers, service accounts and Ingress TLS
+// sections. Controllers scaled to zero still consume what their template
+// references. ReplicaSets and Jobs are folded into the Deployment or CronJob
+// that owns them, so that the owner is listed once.
+func NewConsumers(data *models.AssessmentData) Consumers {
+	index := make(Consumers)
+	add := func(kind, namespace, name string, c Consumer) {
+		k := key{kind, namespace, name}
+		index[k] = append(index[k], c)
+	}
+	addTemplate := func(kind, namespace, name string, t models.PodTemplateInfo) {
+		for _, container := range t.Containers {
+			for _, ref := range container.ConfigReferences {
+				add(ref.Kind, namespace, ref.Name, Consumer{
+					Kind:      kind,
+					Namespace: namespace,
+					Name:      name,
+					Via:       fmt.Sprintf("container %s: %s", container.Name, ref.Via),
+				})
+			}
+		}
+		for _, secret := range t.ImagePullSecrets {
+			add(models.ReferenceSecret, namespace, secret, Consumer{Kind: kind, Namespace: namespace, Name: name, Via: "imagePullSecrets"})
+		}
+	}
+
+	owners := make(map[string]bool)
+	for _, deploy := range data.Workloads.Deployments {
+		owners[deploy.Namespace+"/Deployment/"+deploy.Name] = true
+	}
+	for _, cj := range data.Workloads.CronJobs {
+		owners[cj.Namespace+"/CronJob/"+cj.Name] = true
+	}
+
+	for _, pod := range data.Workloads.Pods {
+		addTemplate("Pod", pod.Namespace, pod.Name, pod.PodTemplateInfo)
+	}
+	for _, deploy := range data.Workloads.Deployments {
+		addTemplate("Deployment", deploy.Namespace, deploy.Name, deploy.Template)
+	}
+	for _, sts := range data.Workloads.StatefulSets {
+		addTemplate("StatefulSet", sts.Namespace, sts.Name, sts.Template)
+	}
+	for _, ds := range data.Workloads.DaemonSets {
+		addTemplate("DaemonSet", ds.Namespace, ds.Name, ds.Template)
+	}
+	for _, rs := range data.Workloads.ReplicaSets {
+		if !owners[rs.Namespace+"/"+rs.Owner] {
+			addTemplate("ReplicaSet", rs.Namespace, rs.Name, rs.Template)
+		}
+	}
+	for _, rc := range data.Workloads.ReplicationControllers {
+		addTemplate("ReplicationController", rc.Namespace, rc.Name, rc.Template)
+	}
+	for _, job := range data.Workloads.Jobs {
+		if !owners[job.Namespace+"/"+job.Owner] {
+			addTemplate("Job", job.Namespace, job.Name, job.Template)
+		}
+	}
+	for _, cj := range data.Workloads.CronJobs {
+		addTemplate("CronJob", cj.Namespace, cj.Name, cj.Template)
+	}
+	for _, sa := range data.RBAC.ServiceAccounts {
+		for _, secret := range sa.Secrets {
+			add(models.ReferenceSecret, sa.Namespace, secret, Consumer{Kind: "ServiceAccount", Namespace: sa.Namespace, Name: sa.Name, Via: "secrets"})
+		}
+		for _, secret := range sa.ImagePullSecrets {
+			add(models.ReferenceSecret, sa.Namespace, secret, Consumer{Kind: "ServiceAccount", Namespace: sa.Namespace, Name: sa.Name, Via: "imagePullSecrets"})
+		}
+	}
+
+	for _, ing := range data.Network.Ingresses {
+		for _, secret := range ing.TLSSecrets {
+			add(models.ReferenceSecret, ing.Namespace, secret, Consumer{Kind: "Ingress", Namespace: ing.Namespace, Name: ing.Name, Via: "tls"})
+		}
+	}
+
+	for k := range index {
+		consumers := index[k]
+		sort.SliceStable(consumers, func(i, j int) bool {
+			if consumers[i].Kind != consumers[j].Kind {
+				return consumers[i].Kind < consumers[j].Kind
+			}
+			return consumers[i].Name < consumers[j].Name
+		})
+	}
+	return index
+}
+
+// Secret returns the consumers of a Secret
+func (c Consumers) Secret(namespace, name string) []Consumer {
+	return c[key{models.ReferenceSecret, namespace, name}]
+}
+
+// ConfigMap returns the consumers of a ConfigMap
+func (c Consumers) ConfigMap(namespace, name string) []Consumer {
+	return c[key{models.ReferenceConfigMap, namespace, name}]
+}
+
+// implicitlyUsedSecretTypes are secret types consumed by Kubernetes or tools
+// rather than through references in pod specs
+var implicitlyUsedSecretTypes = map[string]bool{
+	"kubernetes.io/service-account-token": true,
+	"bootstrap.kubernetes.io/token":       true,
+	"helm.sh/release.v1":                  true,
+}
+
+// Unreferenced reports whether no pod, controller, service account or Ingress consumes
+// the secret, ignoring secret types that are never referenced explicitly
+func (c Consumers) Unreferenced(secret models.SecretInfo) bool {
+	return len(c.Secret(secret.Namespace, secret.Name)) == 0 && !implicitlyUsedSecretTypes[secret.Type]
+}
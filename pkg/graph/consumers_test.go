@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"kubeRadar/pkg/models"
+)
+
+func TestNewConsumers(t *testing.T) {
+	data := &models.AssessmentData{}
+	data.Workloads.Pods = []models.PodInfo{{
+		Name:      "api",
+		Namespace: "payments",
+		PodTemplateInfo: models.PodTemplateInfo{
+			Containers: []models.ContainerInfo{{
+				Name: "api",
+				ConfigReferences: []models.ConfigReference{
+					{Kind: models.ReferenceSecret, Name: "db", Key: "password", Via: "env DB_PASSWORD"},
+					{Kind: models.ReferenceConfigMap, Name: "config", Via: "envFrom"},
+				},
+			}},
+			ImagePullSecrets: []string{"registry"},
+		},
+	}}
+	data.RBAC.ServiceAccounts = []models.ServiceAccountInfo{
+		{Name: "builder", Namespace: "payments", ImagePullSecrets: []string{"registry"}},
+	}
+	data.Network.Ingresses = []models.IngressInfo{
+		{Name: "api", Namespace: "payments", TLS: []string{"pay.example.com"}, TLSSecrets: []string{"api-tls"}},
+	}
+	consumers := NewConsumers(data)
+
+	if got, want := consumers.Secret("payments", "db"), []Consumer{
+		{Kind: "Pod", Namespace: "payments", Name: "api", Via: "container api: env DB_PASSWORD"},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Secret(db) = %+v, want %+v", got, want)
+	}
+	if got := consumers.Secret("payments", "registry"); len(got) != 2 || got[0].Kind != "Pod" || got[1].Kind != "ServiceAccount" {
+		t.Errorf("Secret(registry) = %+v, want the pod and the service account", got)
+	}
+	if got, want := consumers.Secret("payments", "api-tls"), []Consumer{
+		{Kind: "Ingress", Namespace: "payments", Name: "api", Via: "tls"},
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Secret(api-tls) = %+v, want %+v", got, want)
+	}
+	if got := consumers.ConfigMap("payments", "config"); len(got) != 1 {
+		t.Errorf("ConfigMap(config) = %+v, want one consumer", got)
+	}
+	// Same name in another namespace is a different secret
+	if got := consumers.Secret("default", "db"); len(got) != 0 {
+		t.Errorf("Secret(default/db) = %+v, want none", got)
+	}
+
+	for _, tt := range []struct {
+		secret models.SecretInfo
+		want   bool
+	}{
+		{models.SecretInfo{CommonInfo: models.CommonInfo{Name: "db", Namespace: "payments"}, Type: "Opaque"}, false},
+		{models.SecretInfo{CommonInfo: models.CommonInfo{Name: "old", Namespace: "payments"}, Type: "Opaque"}, true},
+		// Referenced by the Ingress only
+		{models.SecretInfo{CommonInfo: models.CommonInfo{Name: "api-tls", Namespace: "payments"}, Type: "kubernetes.io/tls"}, false},
+		{models.SecretInfo{CommonInfo: models.CommonInfo{Name: "sh.helm.release.v1.api.v1", Namespace: "payments"}, Type: "helm.sh/release.v1"}, false},
+	} {
+		if got := consumers.Unreferenced(tt.secret); got != tt.want {
+			t.Errorf("Unreferenced(%s) = %v, want %v", tt.secret.Name, got, tt.want)
+		}
+	}
+}
+
+func TestNewConsumersControllers(t *testing.T) {
+	template := models.PodTemplateInfo{Containers: []models.ContainerInfo{{
+		Name:             "app",
+		ConfigReferences: []models.ConfigReference{{Kind: models.ReferenceSecret, Name: "db", Via: "volume creds"}},
+	}}}
+	data := &models.AssessmentData{}
+	// Scaled to zero, so no pod references the secret
+	data.Workloads.Deployments = []models.DeploymentInfo{{Name: "api", Namespace: "payments", Template: template}}
+	data.Workloads.ReplicaSets = []models.ReplicaSetInfo{
+		{Name: "api-7d9f8", Namespace: "payments", Owner: "Deployment/api", Template: template},
+		{Name: "standalone", Namespace: "payments", Template: template},
+	}
+	data.Workloads.CronJobs = []models.CronJobInfo{{Name: "backup", Namespace: "payments", Template: template}}
+	data.Workloads.Jobs = []models.JobInfo{
+		{Name: "backup-28600000", Namespace: "payments", Owner: "CronJob/backup", Template: template},
+		{Name: "migrate", Namespace: "payments", Template: template},
+	}
+	consumers := NewConsumers(data)
+
+	got := make([]string, 0)
+	for _, c := range consumers.Secret("payments", "db") {
+		got = append(got, c.Kind+" "+c.Name)
+	}
+	want := []string{"CronJob backup", "Deployment api", "Job migrate", "ReplicaSet standalone"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Secret(db) consumers = %v, want %v", got, want)
+	}
+	if consumers.Unreferenced(models.SecretInfo{CommonInfo: models.CommonInfo{Name: "db", Namespace: "payments"}, Type: "Opaque"}) {
+		t.Error("secret of a Deployment scaled to zero reported as unreferenced")
+	}
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"fmt"
+
+	"kubeRadar/pkg/models"
+)
+
+// Exposure relates pods to the Services and Ingresses that make them
+// reachable from outside the cluster network
+type Exposure struct {
+	services []models.ServiceInfo
+	// Ingresses routing to each Service, by namespace/name of the Service
+	ingresses map[string][]string
+}
+
+// NewExposure indexes the Services and the Ingresses routing to them
+func NewExposure(data *models.AssessmentData) Exposure {
+	e := Exposure{services: data.Network.Services, ingresses: make(map[string][]string)}
+	for _, ing := range data.Network.Ingresses {
+		seen := make(map[string]bool)
+		for _, rule := range ing.Rules {
+			for _, path := range rule.Paths {
+				if path.ServiceName == "" || seen[path.ServiceName] {
+					continue
+				}
+				seen[path.ServiceName] = true
+				k := ing.Namespace + "/" + path.ServiceName
+				e.ingresses[k] = append(e.ingresses[k], ing.Name)
+			}
+		}
+	}
+	return e
+}
+
+// Pod lists how a pod is reachable from outside the cluster, e.g.
+// "Service api-public (LoadBalancer)" or "Ingress api → Service api". It is
+// empty for pods only reachable from inside the cluster.
+func (e Exposure) Pod(pod models.PodInfo) []string {
+	exposed := make([]string, 0)
+	for _, svc := range e.services {
+		if svc.Namespace != pod.Namespace || !selects(svc.Selector, pod.Labels) {
+			continue
+		}
+		switch {
+		case svc.Type == "LoadBalancer" || svc.Type == "NodePort":
+			exposed = append(exposed, fmt.Sprintf("Service %s (%s)", svc.Name, svc.Type))
+		case len(svc.ExternalIPs) > 0:
+			exposed = append(exposed, fmt.Sprintf("Service %s (externalIPs)", svc.Name))
+		}
+		for _, ing := range e.ingresses[svc.Namespace+"/"+svc.Name] {
+			exposed = append(exposed, fmt.Sprintf("Ingress %s → Service %s", ing, svc.Name))
+		}
+	}
+	return exposed
+}
+
+// selects reports whether a Service selector matches the pod labels. Services
+// without selector do not select any pod.
+func selects(selector, labels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for k, v := range selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"kubeRadar/pkg/models"
+)
+
+func TestExposure(t *testing.T) {
+	data := &models.AssessmentData{}
+	data.Network.Services = []models.ServiceInfo{
+		{Name: "api", Namespace: "payments", Type: "ClusterIP", Selector: map[string]string{"app": "api"}},
+		{Name: "api-public", Namespace: "payments", Type: "LoadBalancer", Selector: map[string]string{"app": "api"}},
+		{Name: "db", Namespace: "payments", Type: "ClusterIP", Selector: map[string]string{"app": "db"}},
+		{Name: "manual", Namespace: "payments", Type: "NodePort"},
+	}
+	data.Network.Ingresses = []models.IngressInfo{{
+		Name:      "api",
+		Namespace: "payments",
+		Rules: []models.IngressRule{
+			{Host: "pay.example.com", Paths: []models.IngressPath{{Path: "/", ServiceName: "api"}, {Path: "/v2", ServiceName: "api"}}},
+		},
+	}}
+	exposure := NewExposure(data)
+
+	tests := []struct {
+		pod  models.PodInfo
+		want []string
+	}{
+		{models.PodInfo{Name: "api-1", Namespace: "payments", Labels: map[string]string{"app": "api", "tier": "web"}},
+			[]string{"Ingress api → Service api", "Service api-public (LoadBalancer)"}},
+		{models.PodInfo{Name: "db-0", Namespace: "payments", Labels: map[string]string{"app": "db"}}, []string{}},
+		{models.PodInfo{Name: "api-1", Namespace: "staging", Labels: map[string]string{"app": "api"}}, []string{}},
+		{models.PodInfo{Name: "unlabeled", Namespace: "payments"}, []string{}},
+	}
+	for _, tt := range tests {
+		if got := exposure.Pod(tt.pod); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Pod(%s/%s) = %v, want %v", tt.pod.Namespace, tt.pod.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package html renders the assessment as a single self-contained HTML file.
+// Styles, scripts, charts and the logo are inlined, so the report opens in
+// any browser without network access.
+package html
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"os"
+	"strconv"
+
+	"kubeRadar/pkg/assets"
+	"kubeRadar/pkg/excel"
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rules"
+	"kubeRadar/pkg/summary"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var tmpl = template.Must(template.New("report").Parse(reportTemplate))
+
+// Chart geometry in SVG user units
+const (
+	chartLabelWidth = 170
+	chartBarWidth   = 300
+	chartRowHeight  = 26
+)
+
+// Report represents an HTML report generator
+type Report struct {
+	filePath string
+}
+
+func NewReport(filePath string) *Report {
+	return &Report{filePath: filePath}
+}
+
+// page is the data passed to the report template
+type page struct {
+	Logo        template.URL
+	ClusterInfo models.ClusterInfo
+	Dashboard   summary.Dashboard
+	Charts      []chart
+	Tables      []table
+}
+
+type chart struct {
+	Title  string
+	Width  int
+	Height int
+	BarX   int
+	Bars   []bar
+}
+
+type bar struct {
+	Label  string
+	Value  interface{} // the metric value, e.g. summary.NotAnalyzed instead of a count
+	Y      int
+	Width  int
+	ValueX int // position of the value label right of the bar
+	Level  string
+}
+
+type table struct {
+	ID string
+	excel.Table
+}
+
+// Generate renders the data and writes the HTML file
+func (r *Report) Generate(data *models.AssessmentData) error {
+	out, err := Render(data)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.filePath, out, 0o600); err != nil {
+		return fmt.Errorf("failed to write html file: %v", err)
+	}
+	return nil
+}
+
+// Render returns the HTML report for the data
+func Render(data *models.AssessmentData) ([]byte, error) {
+	findings := rules.Evaluate(data)
+	dashboard := summary.NewDashboard(data, findings)
+
+	tables := excel.Tables(data)
+
+	p := page{
+		Logo:        template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(assets.Logo)),
+		ClusterInfo: data.ClusterInfo,
+		Dashboard:   dashboard,
+		Charts: []chart{
+			newChart("Findings by Severity", dashboard.Findings, func(m summary.Metric) string {
+				return excel.SeverityLevel(rules.Severity(m.Label))
+			}),
+			newChart("RBAC Objects Distribution", dashboard.RBAC, nil),
+			newChart("Pod Configurations", dashboard.PodSecurity, nil),
+			newChart("Certificate Expiry", dashboard.Certificates, func(m summary.Metric) string {
+				count, ok := m.Value.(int)
+				switch {
+				case !ok:
+					return ""
+				case count == 0:
+					return "good"
+				case m.Label == "Expired":
+					return "critical"
+				}
+				return "moderate"
+			}),
+		},
+		Tables: make([]table, 0, len(tables)),
+	}
+	for i, t := range tables {
+		p.Tables = append(p.Tables, table{ID: "table-" + strconv.Itoa(i), Table: t})
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, p); err != nil {
+		return nil, fmt.Errorf("failed to render html: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// newChart lays out a horizontal bar chart of integer metrics. level picks
+// the bar color and may be nil.
+func newChart(title string, metrics []summary.Metric, level func(summary.Metric) string) chart {
+	max := 0
+	for _, m := range metrics {
+		if v, ok := m.Value.(int); ok && v > max {
+			max = v
+		}
+	}
+	c := chart{
+		Title:  title,
+		Width:  chartLabelWidth + chartBarWidth + 50,
+		Height: len(metrics) * chartRowHeight,
+		BarX:   chartLabelWidth,
+		Bars:   make([]bar, 0, len(metrics)),
+	}
+	for i, m := range metrics {
+		v, _ := m.Value.(int)
+		b := bar{Label: m.Label, Value: m.Value, Y: i * chartRowHeight}
+		if max > 0 {
+			b.Width = v * chartBarWidth / max
+		}
+		b.ValueX = chartLabelWidth + b.Width + 6
+		if level != nil {
+			b.Level = level(m)
+		}
+		c.Bars = append(c.Bars, b)
+	}
+	return c
+}
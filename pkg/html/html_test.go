@@ -0,0 +1,73 @@
+package html
+
+import (
+	"strings"
+	"testing"
+
+	"kubeRadar/pkg/collector"
+	"kubeRadar/pkg/fixture"
+	"kubeRadar/pkg/models"
+
+	"k8s.io/client-go/rest"
+)
+
+func collectFixture(t *testing.T) *models.AssessmentData {
+	t.Helper()
+	opts := collector.DefaultOptions()
+	opts.Context = "fixture"
+	opts.InspectSecrets = true
+	c := collector.NewCollectorForClients(collector.Clients{Kube: fixture.Clientset()}, &rest.Config{Host: "https://fixture.example.com:6443"}, opts)
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+	data.ClusterInfo.CollectedAt = fixture.CollectedAt.String()
+	return data
+}
+
+func TestRenderGolden(t *testing.T) {
+	out, err := Render(collectFixture(t))
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	fixture.AssertGolden(t, "testdata/report.golden.html", out)
+}
+
+func TestRenderIsSelfContained(t *testing.T) {
+	out, err := Render(collectFixture(t))
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	for _, external := range []string{`src="http`, `href="http`, `url(http`, `@import`} {
+		if strings.Contains(string(out), external) {
+			t.Errorf("report references an external resource: %s", external)
+		}
+	}
+	if !strings.Contains(string(out), `src="data:image/png;base64,`) {
+		t.Error("report does not embed the logo")
+	}
+}
+
+func TestRenderEscapesClusterData(t *testing.T) {
+	data := collectFixture(t)
+	data.Workloads.Pods[0].Labels = map[string]string{"app": "<script>alert(1)</script>"}
+	out, err := Render(data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if strings.Contains(string(out), "<script>alert(1)</script>") {
+		t.Error("cluster data is rendered without escaping")
+	}
+}
+
+func TestRenderCertificatesNotAnalyzed(t *testing.T) {
+	data := collectFixture(t)
+	data.Secrets.Inspected = false
+	out, err := Render(data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if n := strings.Count(string(out), ">not analyzed</text>"); n != 4 {
+		t.Errorf("got %d certificate counts shown as not analyzed, want 4", n)
+	}
+}
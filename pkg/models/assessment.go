@@ -1,14 +1,16 @@
 package models
 
 // ClusterInfo represents basic information about the Kubernetes cluster
-// | Version | NodeCount | APIServer | Platform | Components | Nodes | Namespaces |
+// | Version | NodeCount | APIServer | Context | Platform | Components | Nodes | Namespaces |
 type ClusterInfo struct {
-	Version    string
-	NodeCount  int
-	APIServer  string
-	Platform   string
-	Nodes      []NodeInfo
-	Namespaces []NamespaceInfo
+	Version     string
+	NodeCount   int
+	APIServer   string
+	Context     string // kubeconfig context the data was collected from
+	Platform    string
+	CollectedAt string // certificate expiry is measured against this time
+	Nodes       []NodeInfo
+	Namespaces  []NamespaceInfo
 }
 
 // NodeInfo represents detailed information about a node
@@ -61,13 +63,17 @@ func (r *RBACAssessment) ClusterRolesOnly() []RoleInfo {
 }
 
 // RoleInfo contains information about RBAC roles and cluster roles
-// | Name | Namespace | ClusterRole | Rules | CreatedAt |
+// | Name | Namespace | ClusterRole | Labels | Rules | AggregationRule | CreatedAt |
 type RoleInfo struct {
 	Name        string
 	Namespace   string
 	ClusterRole bool // true if this is a ClusterRole
+	Labels      map[string]string
 	Rules       []PolicyRule
-	CreatedAt   string
+	// AggregationRule holds the label selectors of an aggregated ClusterRole,
+	// whose rules are filled in from the ClusterRoles they match
+	AggregationRule []string
+	CreatedAt       string
 }
 
 // BindingInfo contains information about RBAC bindings
@@ -75,18 +81,32 @@ type RoleInfo struct {
 type BindingInfo struct {
 	Name      string
 	Namespace string
-	RoleRef   string
+	RoleRef   RoleRef
 	Subjects  []Subject
 	CreatedAt string
 }
 
+// RoleRef identifies the Role or ClusterRole a binding grants
+// | APIGroup | Kind | Name |
+type RoleRef struct {
+	APIGroup string
+	Kind     string // Role or ClusterRole
+	Name     string
+}
+
+// String formats the reference as Kind/Name
+func (r RoleRef) String() string {
+	return r.Kind + "/" + r.Name
+}
+
 // PolicyRule represents an RBAC policy rule
-// | APIGroups | Resources | ResourceNames | Verbs |
+// | APIGroups | Resources | ResourceNames | NonResourceURLs | Verbs |
 type PolicyRule struct {
-	APIGroups     []string
-	Resources     []string
-	ResourceNames []string
-	Verbs         []string
+	APIGroups       []string
+	Resources       []string
+	ResourceNames   []string
+	NonResourceURLs []string // only effective in ClusterRoles bound cluster-wide
+	Verbs           []string
 }
 
 // Subject represents a binding subject
@@ -98,44 +118,152 @@ type Subject struct {
 }
 
 // DeploymentInfo contains information about deployments
-// | Name | Namespace | Replicas | UpdateStrategy | Labels | CreatedAt |
+// | Name | Namespace | Replicas | UpdateStrategy | ServiceAccount | AutomountServiceAccountToken | Template | Labels | CreatedAt |
 type DeploymentInfo struct {
-	Name           string
-	Namespace      string
-	Replicas       int32
-	UpdateStrategy string
-	Labels         map[string]string
-	CreatedAt      string
+	Name                         string
+	Namespace                    string
+	Replicas                     int32
+	UpdateStrategy               string
+	ServiceAccount               string // of the pod template
+	AutomountServiceAccountToken *bool
+	Template                     PodTemplateInfo
+	Labels                       map[string]string
+	CreatedAt                    string
 }
 
 // StatefulSetInfo contains information about stateful sets
-// | Name | Namespace | Replicas | UpdateStrategy | Labels | CreatedAt |
+// | Name | Namespace | Replicas | UpdateStrategy | ServiceAccount | AutomountServiceAccountToken | Template | Labels | CreatedAt |
 type StatefulSetInfo struct {
-	Name           string
-	Namespace      string
-	Replicas       int32
-	UpdateStrategy string
-	Labels         map[string]string
-	CreatedAt      string
+	Name                         string
+	Namespace                    string
+	Replicas                     int32
+	UpdateStrategy               string
+	ServiceAccount               string // of the pod template
+	AutomountServiceAccountToken *bool
+	Template                     PodTemplateInfo
+	Labels                       map[string]string
+	CreatedAt                    string
 }
 
 // DaemonSetInfo contains information about daemon sets
-// | Name | Namespace | UpdateStrategy | Labels | CreatedAt |
+// | Name | Namespace | UpdateStrategy | ServiceAccount | AutomountServiceAccountToken | Template | Labels | CreatedAt |
 type DaemonSetInfo struct {
-	Name           string
-	Namespace      string
-	UpdateStrategy string
-	Labels         map[string]string
-	CreatedAt      string
+	Name                         string
+	Namespace                    string
+	UpdateStrategy               string
+	ServiceAccount               string // of the pod template
+	AutomountServiceAccountToken *bool
+	Template                     PodTemplateInfo
+	Labels                       map[string]string
+	CreatedAt                    string
+}
+
+// PodTemplateInfo contains the security-relevant part of a pod template
+// | ServiceAccount | SecurityContext | Containers | Volumes | ImagePullSecrets | AutomountServiceAccountToken |
+type PodTemplateInfo struct {
+	ServiceAccount               string
+	SecurityContext              PodSecurityInfo
+	Containers                   []ContainerInfo
+	Volumes                      []VolumeInfo
+	ImagePullSecrets             []string
+	AutomountServiceAccountToken *bool
+}
+
+// VolumeInfo contains information about a pod volume and where it is mounted
+// | Name | Type | Source | ReadOnly | ServiceAccountToken | TokenAudience | TokenExpirationSeconds | Mounts |
+type VolumeInfo struct {
+	Name     string
+	Type     string // volume source as named in the pod spec, e.g. hostPath
+	Source   string // path, object name or driver the volume is backed by
+	ReadOnly bool   // the volume source itself is read-only
+	// ServiceAccountToken is set for projected volumes with a service account
+	// token, with its audience (empty for the API server) and expiry
+	ServiceAccountToken    bool
+	TokenAudience          string
+	TokenExpirationSeconds *int64
+	Mounts                 []VolumeMountInfo
+}
+
+// VolumeMountInfo contains a mount of a volume into a container
+// | Container | MountPath | SubPath | ReadOnly |
+type VolumeMountInfo struct {
+	Container string
+	MountPath string
+	SubPath   string
+	ReadOnly  bool
+}
+
+// JobInfo contains information about jobs
+// | Name | Namespace | Owner | Completions | Parallelism | BackoffLimit | Active | Succeeded | Failed | Template | Labels | CreatedAt |
+type JobInfo struct {
+	Name         string
+	Namespace    string
+	Owner        string // controlling object as Kind/Name, e.g. CronJob/backup
+	Completions  int32
+	Parallelism  int32
+	BackoffLimit int32
+	Active       int32
+	Succeeded    int32
+	Failed       int32
+	Template     PodTemplateInfo
+	Labels       map[string]string
+	CreatedAt    string
+}
+
+// CronJobInfo contains information about cron jobs
+// | Name | Namespace | Schedule | Suspend | ConcurrencyPolicy | LastScheduleTime | Template | Labels | CreatedAt |
+type CronJobInfo struct {
+	Name              string
+	Namespace         string
+	Schedule          string
+	Suspend           bool
+	ConcurrencyPolicy string
+	LastScheduleTime  string // empty if the job was never scheduled
+	Template          PodTemplateInfo
+	Labels            map[string]string
+	CreatedAt         string
+}
+
+// ReplicaSetInfo contains information about replica sets
+// | Name | Namespace | Owner | Replicas | ReadyReplicas | ServiceAccount | AutomountServiceAccountToken | Template | Labels | CreatedAt |
+type ReplicaSetInfo struct {
+	Name                         string
+	Namespace                    string
+	Owner                        string // controlling object as Kind/Name, e.g. Deployment/api
+	Replicas                     int32
+	ReadyReplicas                int32
+	ServiceAccount               string // of the pod template
+	AutomountServiceAccountToken *bool
+	Template                     PodTemplateInfo
+	Labels                       map[string]string
+	CreatedAt                    string
+}
+
+// ReplicationControllerInfo contains information about replication controllers
+// | Name | Namespace | Replicas | ReadyReplicas | ServiceAccount | AutomountServiceAccountToken | Template | Labels | CreatedAt |
+type ReplicationControllerInfo struct {
+	Name                         string
+	Namespace                    string
+	Replicas                     int32
+	ReadyReplicas                int32
+	ServiceAccount               string // of the pod template
+	AutomountServiceAccountToken *bool
+	Template                     PodTemplateInfo
+	Labels                       map[string]string
+	CreatedAt                    string
 }
 
 // WorkloadAssessment contains information about workloads
-// | Pods | Deployments | StatefulSets | DaemonSets |
+// | Pods | Deployments | StatefulSets | DaemonSets | Jobs | CronJobs | ReplicaSets | ReplicationControllers |
 type WorkloadAssessment struct {
-	Pods         []PodInfo
-	Deployments  []DeploymentInfo
-	StatefulSets []StatefulSetInfo
-	DaemonSets   []DaemonSetInfo
+	Pods                   []PodInfo
+	Deployments            []DeploymentInfo
+	StatefulSets           []StatefulSetInfo
+	DaemonSets             []DaemonSetInfo
+	Jobs                   []JobInfo
+	CronJobs               []CronJobInfo
+	ReplicaSets            []ReplicaSetInfo
+	ReplicationControllers []ReplicationControllerInfo
 }
 
 // CommonInfo for all resources
@@ -147,43 +275,110 @@ type CommonInfo struct {
 	CreatedAt string
 }
 
-// PodInfo contains pod-level information including security context
-// | Name | Namespace | NodeName | ServiceAccount | Labels | CreatedAt | SecurityContext | Containers | AutomountServiceAccountToken |
+// PodInfo contains pod-level information including security context. The
+// pod spec settings are shared with workload templates through PodTemplateInfo.
+// | Name | Namespace | NodeName | Labels | CreatedAt | PodTemplateInfo |
 type PodInfo struct {
-	Name                         string
-	Namespace                    string
-	NodeName                     string
-	ServiceAccount               string
-	Labels                       map[string]string
-	CreatedAt                    string
-	SecurityContext              PodSecurityInfo
-	Containers                   []ContainerInfo
-	AutomountServiceAccountToken *bool
+	Name      string
+	Namespace string
+	NodeName  string
+	Labels    map[string]string
+	CreatedAt string
+	PodTemplateInfo
 }
 
+// Container types, in the order the kubelet starts them. Sidecars are init
+// containers with restartPolicy Always that keep running next to the regular
+// containers; ephemeral containers are added to a running pod by kubectl debug.
+const (
+	ContainerTypeInit      = "init"
+	ContainerTypeSidecar   = "sidecar"
+	ContainerTypeRegular   = "regular"
+	ContainerTypeEphemeral = "ephemeral"
+)
+
 // ContainerInfo contains security-relevant information about containers
-// | Name | Image | SecurityContext | Resources | EnvVars |
+// | Name | Type | Image | ImagePullPolicy | Command | Args | Ports | Probes | SecurityContext | Resources | EnvVars | ConfigReferences |
 type ContainerInfo struct {
 	Name            string
+	Type            string
 	Image           string
+	ImagePullPolicy string   // empty if unset
+	Command         []string // values of secret-looking flags are redacted
+	Args            []string
+	Ports           []ContainerPort
+	Probes          ProbeInfo
 	SecurityContext ContainerSecurityInfo
 	Resources       ResourceRequirements
-	EnvVars         []string
+	EnvVars         []string // names only, values are never collected
+	// ConfigReferences lists the Secrets and ConfigMaps the container reads
+	ConfigReferences []ConfigReference
+}
+
+// Kinds of objects a container can take its configuration from
+const (
+	ReferenceSecret    = "Secret"
+	ReferenceConfigMap = "ConfigMap"
+)
+
+// ConfigReference is a Secret or ConfigMap consumed by a container
+// | Kind | Name | Key | Via |
+type ConfigReference struct {
+	Kind string
+	Name string
+	Key  string // empty when every key is consumed
+	Via  string // how it is consumed: env VAR, envFrom or volume NAME
+}
+
+// ContainerPort contains a port declared by a container
+// | Name | ContainerPort | HostPort | HostIP | Protocol |
+type ContainerPort struct {
+	Name          string
+	ContainerPort int32
+	HostPort      int32 // 0 if the port is not bound on the node
+	HostIP        string
+	Protocol      string
+}
+
+// ProbeInfo contains the health probes of a container, each formatted as
+// its handler and target, e.g. httpGet :8080/healthz, empty if unset
+// | Liveness | Readiness | Startup |
+type ProbeInfo struct {
+	Liveness  string
+	Readiness string
+	Startup   string
 }
 
 // PodSecurityInfo contains pod-level security context information
-// | RunAsUser | RunAsGroup | FSGroup | HostNetwork | HostPID | HostIPC |
+// | RunAsUser | RunAsGroup | FSGroup | SupplementalGroups | Sysctls | HostNetwork | HostPID | HostIPC | ShareProcessNamespace | SeccompProfile | AppArmorProfile | SELinuxOptions | WindowsOptions | RuntimeClassName |
 type PodSecurityInfo struct {
-	RunAsUser   *int64
-	RunAsGroup  *int64
-	FSGroup     *int64
-	HostNetwork bool
-	HostPID     bool
-	HostIPC     bool
+	RunAsUser             *int64
+	RunAsGroup            *int64
+	FSGroup               *int64
+	SupplementalGroups    []int64
+	Sysctls               []Sysctl
+	HostNetwork           bool
+	HostPID               bool
+	HostIPC               bool
+	ShareProcessNamespace bool
+	SeccompProfile        string // RuntimeDefault, Unconfined or Localhost/<profile>, empty if unset
+	AppArmorProfile       string // same format as SeccompProfile
+	SELinuxOptions        string // e.g. type=spc_t, empty if unset
+	WindowsOptions        string // e.g. hostProcess=true, empty if unset
+	RuntimeClassName      string
+}
+
+// Sysctl is a kernel parameter set for a pod
+// | Name | Value |
+type Sysctl struct {
+	Name  string
+	Value string
 }
 
-// ContainerSecurityInfo contains container-level security context
-// | Capabilities | RunAsUser | RunAsNonRoot | ReadOnlyRoot | Privileged | AllowPrivilegeEscalation |
+// ContainerSecurityInfo contains container-level security context. Profiles
+// and options use the PodSecurityInfo format and are empty when the container
+// inherits the pod setting.
+// | Capabilities | RunAsUser | RunAsNonRoot | ReadOnlyRoot | Privileged | AllowPrivilegeEscalation | SeccompProfile | AppArmorProfile | SELinuxOptions | ProcMount | WindowsOptions |
 type ContainerSecurityInfo struct {
 	Capabilities             []string
 	RunAsUser                *int64
@@ -191,6 +386,11 @@ type ContainerSecurityInfo struct {
 	ReadOnlyRoot             bool
 	Privileged               bool
 	AllowPrivilegeEscalation *bool
+	SeccompProfile           string
+	AppArmorProfile          string // from the field or the legacy annotation
+	SELinuxOptions           string
+	ProcMount                string // Default or Unmasked, empty if unset
+	WindowsOptions           string
 }
 
 // ResourceRequirements contains container resource constraints
@@ -216,7 +416,7 @@ type NetworkAssessment struct {
 }
 
 // ServiceInfo represents a Kubernetes Service
-// | Name | Namespace | Labels | CreatedAt | Type | ClusterIP | ExternalIPs | Ports | Size |
+// | Name | Namespace | Labels | CreatedAt | Type | ClusterIP | ExternalIPs | Selector | Ports | Size |
 type ServiceInfo struct {
 	Name        string
 	Namespace   string
@@ -225,6 +425,7 @@ type ServiceInfo struct {
 	Type        string
 	ClusterIP   string
 	ExternalIPs []string
+	Selector    map[string]string // empty for services with manually managed endpoints
 	Ports       []ServicePort
 	Size        int64
 }
@@ -249,14 +450,15 @@ type NetworkPolicyInfo struct {
 }
 
 // IngressInfo represents a Kubernetes Ingress
-// | Name | Namespace | Labels | CreatedAt | Rules | TLS |
+// | Name | Namespace | Labels | CreatedAt | Rules | TLS | TLSSecrets |
 type IngressInfo struct {
-	Name      string
-	Namespace string
-	Labels    map[string]string
-	CreatedAt string
-	Rules     []IngressRule
-	TLS       []string
+	Name       string
+	Namespace  string
+	Labels     map[string]string
+	CreatedAt  string
+	Rules      []IngressRule
+	TLS        []string // hosts served over TLS
+	TLSSecrets []string // Secrets holding their certificates
 }
 
 // IngressRule represents a rule in an Ingress resource
@@ -275,7 +477,7 @@ type IngressPath struct {
 }
 
 // ServiceAccountInfo represents a Kubernetes ServiceAccount
-// | Name | Namespace | Labels | CreatedAt | Secrets | ImagePullSecrets |
+// | Name | Namespace | Labels | CreatedAt | Secrets | ImagePullSecrets | AutomountServiceAccountToken |
 type ServiceAccountInfo struct {
 	Name             string
 	Namespace        string
@@ -283,27 +485,122 @@ type ServiceAccountInfo struct {
 	CreatedAt        string
 	Secrets          []string
 	ImagePullSecrets []string
+	// AutomountServiceAccountToken is the default for pods that do not set it
+	AutomountServiceAccountToken *bool
 }
 
-// SecretInfo represents a Kubernetes Secret
-// | Name | Namespace | Labels | CreatedAt | Type |
+// SecretInfo represents a Kubernetes Secret. Type is empty when only the
+// Secret's metadata was listed and its type cannot be told from it.
+// | Name | Namespace | Labels | CreatedAt | Type | Keys | Certificates | CertificateError |
 type SecretInfo struct {
 	CommonInfo
 	Type string
+	Keys []string // data keys, only collected when Secrets are inspected
+	// Certificates is the tls.crt chain of kubernetes.io/tls Secrets, in the
+	// order it is served, when Secrets are inspected
+	Certificates     []CertificateInfo
+	CertificateError string // why tls.crt could not be parsed
+}
+
+// CertificateInfo describes an X.509 certificate
+// | Subject | SANs | Issuer | NotBefore | NotAfter | KeyAlgorithm | KeySize | SignatureAlgorithm | IsCA | SelfSigned | WeakSignature |
+type CertificateInfo struct {
+	Subject            string
+	SANs               []string // DNS names, IP addresses, emails and URIs
+	Issuer             string
+	NotBefore          string
+	NotAfter           string
+	KeyAlgorithm       string
+	KeySize            int // in bits
+	SignatureAlgorithm string
+	IsCA               bool
+	SelfSigned         bool
+	WeakSignature      bool // signed with MD5 or SHA-1
 }
 
 // SecretAssessment contains information about Kubernetes Secrets
-// | Secrets |
+// | Secrets | Inspected |
 type SecretAssessment struct {
 	Secrets []SecretInfo
+	// Inspected records whether Secret payloads were read, so that keys and
+	// certificates were collected
+	Inspected bool
+}
+
+// ConfigMapInfo represents a Kubernetes ConfigMap. Values are never kept,
+// only the names of the keys whose value looks like a credential.
+// | Name | Namespace | Labels | CreatedAt | Keys | Size | SuspectedCredentials |
+type ConfigMapInfo struct {
+	CommonInfo
+	Keys                 []string // data and binaryData keys, sorted
+	Size                 int      // total size of all values in bytes
+	SuspectedCredentials []SuspectedCredential
+}
+
+// SuspectedCredential is a ConfigMap key whose value matches a credential pattern
+// | Key | Kinds |
+type SuspectedCredential struct {
+	Key   string
+	Kinds []string
+}
+
+// ConfigMapAssessment contains information about Kubernetes ConfigMaps
+// | ConfigMaps |
+type ConfigMapAssessment struct {
+	ConfigMaps []ConfigMapInfo
+}
+
+// Coverage statuses for a collected resource kind
+const (
+	CoverageComplete = "Complete"
+	CoveragePartial  = "Partial"
+	CoverageFailed   = "Failed"
+)
+
+// KindCoverage summarizes how completely a resource kind was collected
+// | Kind | Status | Collected | FailedNamespaces |
+type KindCoverage struct {
+	Kind             string
+	Status           string
+	Collected        int
+	FailedNamespaces int
+}
+
+// CollectionError records a List call that failed during collection
+// | Kind | Namespace | Reason | Message |
+type CollectionError struct {
+	Kind      string
+	Namespace string // empty for cluster-wide lists
+	Reason    string
+	Message   string
+}
+
+// CoverageAssessment records what the collector could and could not see
+// | Kinds | Errors |
+type CoverageAssessment struct {
+	Kinds  []KindCoverage
+	Errors []CollectionError
+}
+
+// Incomplete returns the kinds that were not fully collected
+func (c *CoverageAssessment) Incomplete() []KindCoverage {
+	kinds := make([]KindCoverage, 0)
+	for _, k := range c.Kinds {
+		if k.Status != CoverageComplete {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
 }
 
 // AssessmentData represents all collected assessment data
-// | ClusterInfo | RBAC | Workloads | Network | Secrets |
+// | ClusterInfo | RBAC | Workloads | Network | Secrets | ConfigMaps | Coverage |
 type AssessmentData struct {
 	ClusterInfo ClusterInfo
 	RBAC        RBACAssessment
 	Workloads   WorkloadAssessment
 	Network     NetworkAssessment
 	Secrets     SecretAssessment
+	ConfigMaps  ConfigMapAssessment
+	Coverage    CoverageAssessment
 }
@@ -0,0 +1,68 @@
+package rbac
+
+import (
+	"sort"
+	"strings"
+
+	"kubeRadar/pkg/models"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AggregationSources returns, for every aggregated ClusterRole, the sorted
+// names of the ClusterRoles its aggregation rule selects. The controller
+// copies their rules into the aggregated role, so adding a matching label to
+// any ClusterRole silently extends it.
+func AggregationSources(clusterRoles []models.RoleInfo) map[string][]string {
+	sources := make(map[string][]string)
+	for _, aggregated := range clusterRoles {
+		if len(aggregated.AggregationRule) == 0 {
+			continue
+		}
+		selectors := make([]labels.Selector, 0, len(aggregated.AggregationRule))
+		for _, s := range aggregated.AggregationRule {
+			// An empty selector is formatted as <none> and selects every ClusterRole
+			if s == "<none>" {
+				selectors = append(selectors, labels.Everything())
+			} else if selector, err := labels.Parse(s); err == nil {
+				selectors = append(selectors, selector)
+			}
+		}
+
+		names := make([]string, 0)
+		for _, role := range clusterRoles {
+			if role.Name == aggregated.Name {
+				continue
+			}
+			for _, selector := range selectors {
+				if selector.Matches(labels.Set(role.Labels)) {
+					names = append(names, role.Name)
+					break
+				}
+			}
+		}
+		sort.Strings(names)
+		sources[aggregated.Name] = names
+	}
+	return sources
+}
+
+// userFacingRoles are the default ClusterRoles meant to be granted to users
+var userFacingRoles = map[string]bool{"cluster-admin": true, "admin": true, "edit": true, "view": true}
+
+// BuiltIn reports whether a ClusterRole is one Kubernetes creates by default
+func BuiltIn(name string) bool {
+	return userFacingRoles[name] || strings.HasPrefix(name, "system:")
+}
+
+// ThirdPartySources returns the aggregation sources that are not ClusterRoles
+// Kubernetes creates by default
+func ThirdPartySources(sources []string) []string {
+	thirdParty := make([]string, 0)
+	for _, name := range sources {
+		if !BuiltIn(name) {
+			thirdParty = append(thirdParty, name)
+		}
+	}
+	return thirdParty
+}
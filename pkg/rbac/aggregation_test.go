@@ -0,0 +1,51 @@
+package rbac
+
+import (
+	"strings"
+	"testing"
+
+	"kubeRadar/pkg/models"
+)
+
+func TestAggregationSources(t *testing.T) {
+	roles := []models.RoleInfo{
+		{Name: "admin", AggregationRule: []string{"rbac.authorization.k8s.io/aggregate-to-admin=true"}},
+		{Name: "monitoring", AggregationRule: []string{"team in (sre,platform)", "rbac.example.com/aggregate-to-monitoring"}},
+		{Name: "system:aggregate-to-admin", Labels: map[string]string{"rbac.authorization.k8s.io/aggregate-to-admin": "true"}},
+		{Name: "backup-operator", Labels: map[string]string{"rbac.authorization.k8s.io/aggregate-to-admin": "true", "team": "sre"}},
+		{Name: "metrics-reader", Labels: map[string]string{"rbac.example.com/aggregate-to-monitoring": ""}},
+		{Name: "unrelated", Labels: map[string]string{"rbac.authorization.k8s.io/aggregate-to-admin": "false"}},
+	}
+	sources := AggregationSources(roles)
+	want := map[string]string{
+		"admin":      "backup-operator,system:aggregate-to-admin",
+		"monitoring": "backup-operator,metrics-reader",
+	}
+	if len(sources) != len(want) {
+		t.Fatalf("AggregationSources = %v, want %v", sources, want)
+	}
+	for name, w := range want {
+		if got := strings.Join(sources[name], ","); got != w {
+			t.Errorf("sources of %s = %s, want %s", name, got, w)
+		}
+	}
+	if got := ThirdPartySources(sources["admin"]); len(got) != 1 || got[0] != "backup-operator" {
+		t.Errorf("ThirdPartySources = %v, want [backup-operator]", got)
+	}
+}
+
+func TestAllowsNonResourceURLs(t *testing.T) {
+	rule := models.PolicyRule{NonResourceURLs: []string{"/metrics", "/debug/*"}, Verbs: []string{"get"}}
+	tests := map[string]bool{
+		"/metrics":          true,
+		"/metrics/cadvisor": false,
+		"/debug/pprof":      true,
+		"/healthz":          false,
+		"metrics":           false,
+	}
+	for url, want := range tests {
+		if got := Allows(rule, "get", url); got != want {
+			t.Errorf("Allows(get, %q) = %v, want %v", url, got, want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+// Package rbac resolves RoleBindings and ClusterRoleBindings into the
+// effective permissions of each subject, so reviewers do not have to join the
+// role and binding sheets by hand.
+package rbac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kubeRadar/pkg/models"
+)
+
+// Subject kinds used in bindings
+const (
+	KindUser           = "User"
+	KindGroup          = "Group"
+	KindServiceAccount = "ServiceAccount"
+)
+
+// Grant is a single policy rule a subject receives through a binding
+// | Subject | Namespace | Rule | RoleKind | RoleName | BindingKind | BindingNamespace | BindingName |
+type Grant struct {
+	Subject models.Subject
+	// Namespace the rule applies in, empty for cluster-wide grants
+	Namespace        string
+	Rule             models.PolicyRule
+	RoleKind         string
+	RoleName         string
+	BindingKind      string
+	BindingNamespace string
+	BindingName      string
+}
+
+// ClusterWide reports whether the grant applies in every namespace
+func (g Grant) ClusterWide() bool {
+	return g.Namespace == ""
+}
+
+// Via describes the binding and role the grant comes from
+func (g Grant) Via() string {
+	binding := g.BindingName
+	if g.BindingNamespace != "" {
+		binding = g.BindingNamespace + "/" + g.BindingName
+	}
+	return fmt.Sprintf("%s %s → %s %s", g.BindingKind, binding, g.RoleKind, g.RoleName)
+}
+
+// Resolver holds the grants of every subject named in a binding
+type Resolver struct {
+	grants []Grant
+}
+
+// NewResolver expands every binding into one grant per subject and rule.
+// Bindings whose role was not collected grant nothing.
+func NewResolver(data models.RBACAssessment) *Resolver {
+	clusterRoles := make(map[string]models.RoleInfo)
+	for _, role := range data.ClusterRoles {
+		clusterRoles[role.Name] = role
+	}
+	roles := make(map[string]models.RoleInfo)
+	for _, role := range data.Roles {
+		roles[role.Namespace+"/"+role.Name] = role
+	}
+
+	r := &Resolver{grants: make([]Grant, 0)}
+	add := func(bindingKind string, binding models.BindingInfo, role models.RoleInfo, roleKind string) {
+		for _, subject := range binding.Subjects {
+			for _, rule := range role.Rules {
+				// Non-resource URLs can only be granted cluster-wide
+				if len(rule.Resources) == 0 && (len(rule.NonResourceURLs) == 0 || binding.Namespace != "") {
+					continue
+				}
+				r.grants = append(r.grants, Grant{
+					Subject:          subject,
+					Namespace:        binding.Namespace,
+					Rule:             rule,
+					RoleKind:         roleKind,
+					RoleName:         role.Name,
+					BindingKind:      bindingKind,
+					BindingNamespace: binding.Namespace,
+					BindingName:      binding.Name,
+				})
+			}
+		}
+	}
+
+	resolve := func(bindingKind string, binding models.BindingInfo) {
+		switch binding.RoleRef.Kind {
+		case "ClusterRole":
+			if role, ok := clusterRoles[binding.RoleRef.Name]; ok {
+				add(bindingKind, binding, role, "ClusterRole")
+			}
+		case "Role":
+			// Roles can only be referenced from a RoleBinding of their namespace
+			if role, ok := roles[binding.Namespace+"/"+binding.RoleRef.Name]; ok && binding.Namespace != "" {
+				add(bindingKind, binding, role, "Role")
+			}
+		}
+	}
+	for _, binding := range data.ClusterRoleBindings {
+		resolve("ClusterRoleBinding", binding)
+	}
+	for _, binding := range data.RoleBindings {
+		resolve("RoleBinding", binding)
+	}
+
+	sort.SliceStable(r.grants, func(i, j int) bool {
+		a, b := r.grants[i], r.grants[j]
+		if SubjectName(a.Subject) != SubjectName(b.Subject) {
+			return SubjectName(a.Subject) < SubjectName(b.Subject)
+		}
+		return a.Namespace < b.Namespace
+	})
+	return r
+}
+
+// Grants returns every grant, ordered by subject and namespace
+func (r *Resolver) Grants() []Grant {
+	return r.grants
+}
+
+// WhatCan returns the grants that apply to the subject, including those
+// received through the groups Kubernetes adds it to implicitly, such as
+// system:serviceaccounts:<namespace> for service accounts
+func (r *Resolver) WhatCan(subject models.Subject) []Grant {
+	identities := append([]models.Subject{subject}, ImplicitGroups(subject)...)
+	grants := make([]Grant, 0)
+	for _, g := range r.grants {
+		for _, id := range identities {
+			if sameSubject(g.Subject, id) {
+				grants = append(grants, g)
+				break
+			}
+		}
+	}
+	return grants
+}
+
+// WhoCan returns the grants allowing verb on resource. namespace restricts
+// the result to grants that apply in it; empty matches grants anywhere.
+func (r *Resolver) WhoCan(verb, resource, namespace string) []Grant {
+	grants := make([]Grant, 0)
+	for _, g := range r.grants {
+		if namespace != "" && !g.ClusterWide() && g.Namespace != namespace {
+			continue
+		}
+		if Allows(g.Rule, verb, resource) {
+			grants = append(grants, g)
+		}
+	}
+	return grants
+}
+
+// Allows reports whether the rule permits verb on resource. resource may
+// carry a subresource and an API group, as in pods/exec or
+// deployments.apps; without a group it names a resource of the core group,
+// so only rules whose APIGroups contain "" or "*" match it.
+// Rules restricted to resource names are considered to match. Resources
+// starting with / are non-resource URLs such as /metrics.
+func Allows(rule models.PolicyRule, verb, resource string) bool {
+	if !matches(rule.Verbs, verb) {
+		return false
+	}
+	if strings.HasPrefix(resource, "/") {
+		for _, url := range rule.NonResourceURLs {
+			if url == "*" || url == resource {
+				return true
+			}
+			if prefix, ok := strings.CutSuffix(url, "*"); ok && strings.HasPrefix(resource, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	name, group := splitResource(resource)
+	if !matches(rule.APIGroups, group) {
+		return false
+	}
+	base, sub, hasSub := strings.Cut(name, "/")
+	for _, r := range rule.Resources {
+		if r == "*" || r == name || (hasSub && r == "*/"+sub) {
+			return true
+		}
+		if !hasSub && r == base {
+			return true
+		}
+	}
+	return false
+}
+
+// splitResource separates a resource like deployments.apps or pods/exec
+// into the resource with its subresource and the API group, which is empty
+// for the core group
+func splitResource(resource string) (name, group string) {
+	name, sub, hasSub := strings.Cut(resource, "/")
+	name, group, _ = strings.Cut(name, ".")
+	if hasSub {
+		name += "/" + sub
+	}
+	return name, group
+}
+
+func matches(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ImplicitGroups returns the groups the API server adds a subject to on
+// authentication
+func ImplicitGroups(subject models.Subject) []models.Subject {
+	group := func(name string) models.Subject { return models.Subject{Kind: KindGroup, Name: name} }
+	switch subject.Kind {
+	case KindServiceAccount:
+		return []models.Subject{
+			group("system:serviceaccounts"),
+			group("system:serviceaccounts:" + subject.Namespace),
+			group("system:authenticated"),
+		}
+	case KindUser:
+		if subject.Name == "system:anonymous" {
+			return []models.Subject{group("system:unauthenticated")}
+		}
+		return []models.Subject{group("system:authenticated")}
+	}
+	return nil
+}
+
+// ParseSubject reads a subject written as Kind:name, with ServiceAccounts as
+// ServiceAccount:namespace/name, or as a service account username like
+// system:serviceaccount:namespace:name. A bare name is taken as a User.
+func ParseSubject(s string) (models.Subject, error) {
+	if rest, ok := strings.CutPrefix(s, "system:serviceaccount:"); ok {
+		namespace, name, found := strings.Cut(rest, ":")
+		if !found || namespace == "" || name == "" {
+			return models.Subject{}, fmt.Errorf("invalid service account username %q", s)
+		}
+		return models.Subject{Kind: KindServiceAccount, Namespace: namespace, Name: name}, nil
+	}
+
+	kind, name, found := strings.Cut(s, ":")
+	switch {
+	case !found:
+		return models.Subject{Kind: KindUser, Name: s}, nil
+	case strings.EqualFold(kind, KindUser):
+		return models.Subject{Kind: KindUser, Name: name}, nil
+	case strings.EqualFold(kind, KindGroup):
+		return models.Subject{Kind: KindGroup, Name: name}, nil
+	case strings.EqualFold(kind, KindServiceAccount):
+		namespace, saName, found := strings.Cut(name, "/")
+		if !found || namespace == "" || saName == "" {
+			return models.Subject{}, fmt.Errorf("service account %q must be written as ServiceAccount:namespace/name", s)
+		}
+		return models.Subject{Kind: KindServiceAccount, Namespace: namespace, Name: saName}, nil
+	}
+	// Names like system:kube-proxy contain colons themselves
+	return models.Subject{Kind: KindUser, Name: s}, nil
+}
+
+// SubjectName formats a subject the way ParseSubject reads it
+func SubjectName(s models.Subject) string {
+	if s.Kind == KindServiceAccount {
+		return fmt.Sprintf("%s:%s/%s", s.Kind, s.Namespace, s.Name)
+	}
+	return s.Kind + ":" + s.Name
+}
+
+func sameSubject(a, b models.Subject) bool {
+	if a.Kind != b.Kind || a.Name != b.Name {
+		return false
+	}
+	return a.Kind != KindServiceAccount || a.Namespace == b.Namespace
+}
@@ -0,0 +1,117 @@
+package rbac
+
+import (
+	"testing"
+
+	"kubeRadar/pkg/models"
+)
+
+func testRBAC() models.RBACAssessment {
+	return models.RBACAssessment{
+		ClusterRoles: []models.RoleInfo{
+			{Name: "secret-reader", ClusterRole: true, Rules: []models.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}},
+			}},
+			{Name: "debugger", ClusterRole: true, Rules: []models.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"create"}},
+			}},
+			{Name: "apps-reader", ClusterRole: true, Rules: []models.PolicyRule{
+				{APIGroups: []string{"apps"}, Resources: []string{"*"}, Verbs: []string{"get", "list"}},
+			}},
+		},
+		ClusterRoleBindings: []models.BindingInfo{
+			{Name: "ops-secrets", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "secret-reader"}, Subjects: []models.Subject{{Kind: KindUser, Name: "alice"}}},
+			{Name: "apps-readers", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "apps-reader"}, Subjects: []models.Subject{{Kind: KindUser, Name: "carol"}}},
+		},
+		Roles: []models.RoleInfo{
+			{Name: "secret-reader", Namespace: "payments", Rules: []models.PolicyRule{
+				{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"*"}},
+			}},
+		},
+		RoleBindings: []models.BindingInfo{
+			{Name: "deployers", Namespace: "payments", RoleRef: models.RoleRef{Kind: "Role", Name: "secret-reader"}, Subjects: []models.Subject{{Kind: KindServiceAccount, Namespace: "ci", Name: "deployer"}}},
+			{Name: "debug", Namespace: "payments", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "debugger"}, Subjects: []models.Subject{{Kind: KindGroup, Name: "system:serviceaccounts:ci"}}},
+			{Name: "missing", Namespace: "payments", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "absent"}, Subjects: []models.Subject{{Kind: KindUser, Name: "bob"}}},
+		},
+	}
+}
+
+func TestWhoCan(t *testing.T) {
+	r := NewResolver(testRBAC())
+	tests := []struct {
+		verb, resource, namespace string
+		want                      []string
+	}{
+		{"get", "secrets", "", []string{"User:alice"}},
+		{"get", "secrets", "payments", []string{"User:alice"}},
+		{"delete", "secrets", "", nil},
+		{"get", "deployments.apps", "default", []string{"User:carol"}},
+		{"list", "pods", "", nil},
+		{"patch", "deployments.apps", "payments", []string{"ServiceAccount:ci/deployer"}},
+		{"patch", "deployments", "payments", nil},
+		{"patch", "deployments.extensions", "payments", nil},
+		{"patch", "deployments", "default", nil},
+		{"create", "pods/exec", "", []string{"Group:system:serviceaccounts:ci"}},
+		{"create", "pods", "", nil},
+	}
+	for _, tt := range tests {
+		got := make([]string, 0)
+		for _, g := range r.WhoCan(tt.verb, tt.resource, tt.namespace) {
+			got = append(got, SubjectName(g.Subject))
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("WhoCan(%q, %q, %q) = %v, want %v", tt.verb, tt.resource, tt.namespace, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("WhoCan(%q, %q, %q) = %v, want %v", tt.verb, tt.resource, tt.namespace, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestWhatCanIncludesImplicitGroups(t *testing.T) {
+	r := NewResolver(testRBAC())
+	grants := r.WhatCan(models.Subject{Kind: KindServiceAccount, Namespace: "ci", Name: "deployer"})
+	if len(grants) != 2 {
+		t.Fatalf("WhatCan returned %d grants, want 2: %+v", len(grants), grants)
+	}
+	via := map[string]bool{}
+	for _, g := range grants {
+		via[g.Via()] = true
+	}
+	for _, want := range []string{
+		"RoleBinding payments/deployers → Role secret-reader",
+		"RoleBinding payments/debug → ClusterRole debugger",
+	} {
+		if !via[want] {
+			t.Errorf("WhatCan is missing the grant via %s: %v", want, via)
+		}
+	}
+}
+
+func TestParseSubject(t *testing.T) {
+	tests := map[string]models.Subject{
+		"alice":                              {Kind: KindUser, Name: "alice"},
+		"User:alice@example.com":             {Kind: KindUser, Name: "alice@example.com"},
+		"group:system:masters":               {Kind: KindGroup, Name: "system:masters"},
+		"ServiceAccount:payments/api":        {Kind: KindServiceAccount, Namespace: "payments", Name: "api"},
+		"system:serviceaccount:payments:api": {Kind: KindServiceAccount, Namespace: "payments", Name: "api"},
+		"system:kube-proxy":                  {Kind: KindUser, Name: "system:kube-proxy"},
+	}
+	for in, want := range tests {
+		got, err := ParseSubject(in)
+		if err != nil {
+			t.Errorf("ParseSubject(%q) failed: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseSubject(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+	if _, err := ParseSubject("ServiceAccount:api"); err == nil {
+		t.Error("ParseSubject accepted a service account without namespace")
+	}
+}
@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"kubeRadar/pkg/models"
+)
+
+// CertificateExpiryWindows are the day counts the dashboard groups expiring
+// certificates by
+var CertificateExpiryWindows = []int{30, 60, 90}
+
+// minRSAKeySize is the smallest RSA key considered strong enough
+const minRSAKeySize = 2048
+
+// timeLayout parses the timestamps written by the collector
+const timeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// ReferenceTime returns the time certificate expiry is measured against: when
+// the data was collected, or now for snapshots that do not record it
+func ReferenceTime(data *models.AssessmentData) time.Time {
+	if t, err := time.Parse(timeLayout, data.ClusterInfo.CollectedAt); err == nil {
+		return t
+	}
+	return time.Now().UTC()
+}
+
+// DaysUntilExpiry returns the whole days left before the certificate
+// expires, negative once it has. ok is false when NotAfter is unreadable.
+func DaysUntilExpiry(cert models.CertificateInfo, now time.Time) (days int, ok bool) {
+	notAfter, err := time.Parse(timeLayout, cert.NotAfter)
+	if err != nil {
+		return 0, false
+	}
+	return int(math.Floor(notAfter.Sub(now).Hours() / 24)), true
+}
+
+// WeakKey reports whether the certificate's RSA key is shorter than 2048 bits
+func WeakKey(cert models.CertificateInfo) bool {
+	return cert.KeyAlgorithm == "RSA" && cert.KeySize > 0 && cert.KeySize < minRSAKeySize
+}
+
+func checkCertificates(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	now := ReferenceTime(data)
+	window := CertificateExpiryWindows[0]
+
+	for _, secret := range data.Secrets.Secrets {
+		newFinding := func(id string, sev Severity, title, detail, remediation string) Finding {
+			return Finding{
+				ID:          id,
+				Severity:    sev,
+				Category:    "Secrets",
+				Kind:        "Secret",
+				Namespace:   secret.Namespace,
+				Name:        secret.Name,
+				Title:       title,
+				Detail:      detail,
+				Remediation: remediation,
+			}
+		}
+
+		expired := make([]string, 0)
+		expiring := make([]string, 0)
+		weak := make([]string, 0)
+		for _, cert := range secret.Certificates {
+			if days, ok := DaysUntilExpiry(cert, now); ok {
+				switch {
+				case days < 0:
+					expired = append(expired, fmt.Sprintf("%s (expired %s)", cert.Subject, cert.NotAfter))
+				case days <= window:
+					expiring = append(expiring, fmt.Sprintf("%s (%d days left)", cert.Subject, days))
+				}
+			}
+			if cert.WeakSignature {
+				weak = append(weak, fmt.Sprintf("%s: %s signature", cert.Subject, cert.SignatureAlgorithm))
+			}
+			if WeakKey(cert) {
+				weak = append(weak, fmt.Sprintf("%s: %d-bit RSA key", cert.Subject, cert.KeySize))
+			}
+		}
+
+		if len(expired) > 0 {
+			findings = append(findings, newFinding("KR-SEC-001", SeverityHigh,
+				"Expired TLS certificate",
+				strings.Join(expired, ", "),
+				"Renew the certificate and update the Secret; clients reject the expired chain."))
+		}
+		if len(expiring) > 0 {
+			findings = append(findings, newFinding("KR-SEC-002", SeverityMedium,
+				fmt.Sprintf("TLS certificate expires within %d days", window),
+				strings.Join(expiring, ", "),
+				"Renew the certificate before it expires, or let cert-manager manage its renewal."))
+		}
+		if len(weak) > 0 {
+			findings = append(findings, newFinding("KR-SEC-003", SeverityMedium,
+				"Weak TLS certificate",
+				strings.Join(weak, ", "),
+				"Reissue the certificate with a SHA-256 or stronger signature and an RSA key of at least 2048 bits or an ECDSA key."))
+		}
+		if len(secret.Certificates) > 0 && secret.Certificates[0].SelfSigned && !secret.Certificates[0].IsCA {
+			findings = append(findings, newFinding("KR-SEC-004", SeverityLow,
+				"Self-signed TLS certificate",
+				fmt.Sprintf("%s is signed by its own key, so clients cannot verify it without pinning", secret.Certificates[0].Subject),
+				"Issue the certificate from a trusted CA, or an internal CA distributed to the clients."))
+		}
+	}
+	return findings
+}
@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeRadar/pkg/models"
+)
+
+func checkConfigMapCredentials(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	for _, cm := range data.ConfigMaps.ConfigMaps {
+		if len(cm.SuspectedCredentials) == 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:          "KR-CFG-001",
+			Severity:    SeverityHigh,
+			Category:    "Secrets",
+			Kind:        "ConfigMap",
+			Namespace:   cm.Namespace,
+			Name:        cm.Name,
+			Title:       "Credential stored in ConfigMap",
+			Detail:      fmt.Sprintf("Keys with values that look like credentials: %s", FormatSuspectedCredentials(cm.SuspectedCredentials)),
+			Remediation: "Move the values into a Secret, or an external secret store, and rotate the exposed credentials; ConfigMaps are readable by far more principals than Secrets.",
+		})
+	}
+	return findings
+}
+
+// FormatSuspectedCredentials lists the keys of suspected credentials with the
+// kinds they matched, e.g. "DATABASE_URL (connection string with password)"
+func FormatSuspectedCredentials(suspected []models.SuspectedCredential) string {
+	parts := make([]string, 0, len(suspected))
+	for _, s := range suspected {
+		parts = append(parts, fmt.Sprintf("%s (%s)", s.Key, strings.Join(s.Kinds, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
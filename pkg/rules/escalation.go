@@ -0,0 +1,518 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"kubeRadar/pkg/graph"
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rbac"
+)
+
+// Outcomes an escalation path can end in. Namespaced outcomes are combined
+// with the namespace they apply to.
+const (
+	OutcomeClusterAdmin      = "cluster-admin"
+	OutcomeNodeCompromise    = "node compromise"
+	OutcomeAllSecrets        = "read every Secret"
+	OutcomeMutateRequests    = "mutate API requests"
+	OutcomeInterceptRequests = "intercept API requests"
+	OutcomeNamespaceAdmin    = "admin of namespace"
+	OutcomeNamespaceSecrets  = "read Secrets of namespace"
+)
+
+// outcomeSeverity ranks what reaching an outcome means for the cluster
+var outcomeSeverity = map[string]Severity{
+	OutcomeClusterAdmin:      SeverityCritical,
+	OutcomeNodeCompromise:    SeverityCritical,
+	OutcomeAllSecrets:        SeverityCritical,
+	OutcomeMutateRequests:    SeverityCritical,
+	OutcomeInterceptRequests: SeverityHigh,
+	OutcomeNamespaceAdmin:    SeverityHigh,
+	OutcomeNamespaceSecrets:  SeverityHigh,
+}
+
+// techniqueWildcard is the technique of rules granting every verb on every
+// resource
+const techniqueWildcard = "use any verb on any resource"
+
+// maxEscalationSteps bounds the length of the paths that are searched
+const maxEscalationSteps = 4
+
+// privilegedNamespaceLabel is the Pod Security Admission label that lets
+// pods of a namespace run privileged
+const privilegedNamespaceLabel = "pod-security.kubernetes.io/enforce"
+
+// EscalationStep is a single move along an escalation path: an identity
+// using one of its grants to gain another identity or reach an outcome
+// | Identity | Technique | Scope | Via | Result |
+type EscalationStep struct {
+	Identity  models.Subject
+	Technique string
+	Scope     string
+	Via       string
+	Result    string
+	role      models.RoleRef // role of the grant used, described by Via
+}
+
+// EscalationPath is the shortest chain of steps from a subject to an outcome
+// | Subject | Severity | Outcome | Namespace | Steps |
+type EscalationPath struct {
+	Subject  models.Subject
+	Severity Severity
+	Outcome  string
+	// Namespace the outcome is limited to, empty for cluster-wide outcomes
+	Namespace string
+	Steps     []EscalationStep
+}
+
+// Target describes the outcome, including its namespace
+func (p EscalationPath) Target() string {
+	if p.Namespace != "" {
+		return fmt.Sprintf("%s %s", p.Outcome, p.Namespace)
+	}
+	return p.Outcome
+}
+
+// Describe writes the path one step per line
+func (p EscalationPath) Describe() string {
+	lines := make([]string, 0, len(p.Steps))
+	for i, s := range p.Steps {
+		lines = append(lines, fmt.Sprintf("%d. %s can %s (%s, %s) → %s",
+			i+1, rbac.SubjectName(s.Identity), s.Technique, s.Scope, s.Via, s.Result))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escalationMove is what a single grant lets an identity do next: either
+// reach an outcome or act as other service accounts
+type escalationMove struct {
+	step      EscalationStep
+	outcome   string
+	namespace string
+	pivots    []models.Subject
+}
+
+// EscalationAnalysis finds the grants that let a subject gain further
+// permissions and chains them into escalation paths
+type EscalationAnalysis struct {
+	resolver   *rbac.Resolver
+	privileged map[string]bool
+	// service accounts per namespace: all of them, those with a long-lived
+	// token Secret, and those used by running pods
+	serviceAccounts map[string][]models.Subject
+	tokenAccounts   map[string][]models.Subject
+	podAccounts     map[string][]models.Subject
+	// automountServiceAccountToken of each service account, by namespace/name
+	automount map[string]*bool
+}
+
+// NewEscalationAnalysis indexes the RBAC objects, namespaces and pods the
+// escalation techniques depend on
+func NewEscalationAnalysis(data *models.AssessmentData) *EscalationAnalysis {
+	e := &EscalationAnalysis{
+		resolver:        rbac.NewResolver(data.RBAC),
+		privileged:      map[string]bool{"kube-system": true},
+		serviceAccounts: make(map[string][]models.Subject),
+		tokenAccounts:   make(map[string][]models.Subject),
+		podAccounts:     make(map[string][]models.Subject),
+		automount:       make(map[string]*bool),
+	}
+	for _, ns := range data.ClusterInfo.Namespaces {
+		if ns.Labels[privilegedNamespaceLabel] == "privileged" {
+			e.privileged[ns.Name] = true
+		}
+	}
+	for _, sa := range data.RBAC.ServiceAccounts {
+		subject := models.Subject{Kind: rbac.KindServiceAccount, Namespace: sa.Namespace, Name: sa.Name}
+		e.serviceAccounts[sa.Namespace] = append(e.serviceAccounts[sa.Namespace], subject)
+		e.automount[sa.Namespace+"/"+sa.Name] = sa.AutomountServiceAccountToken
+		if len(sa.Secrets) > 0 {
+			e.tokenAccounts[sa.Namespace] = append(e.tokenAccounts[sa.Namespace], subject)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, pod := range data.Workloads.Pods {
+		name := pod.ServiceAccount
+		if name == "" {
+			name = "default"
+		}
+		subject := models.Subject{Kind: rbac.KindServiceAccount, Namespace: pod.Namespace, Name: name}
+		if !seen[rbac.SubjectName(subject)] {
+			seen[rbac.SubjectName(subject)] = true
+			e.podAccounts[pod.Namespace] = append(e.podAccounts[pod.Namespace], subject)
+		}
+	}
+	return e
+}
+
+// Risks names the escalation techniques a grant enables. Wildcard rules
+// enable every technique and are only reported as such.
+func (e *EscalationAnalysis) Risks(g rbac.Grant) []string {
+	risks := make([]string, 0)
+	for _, m := range e.grantMoves(g) {
+		if m.step.Technique == techniqueWildcard {
+			return []string{techniqueWildcard}
+		}
+		if !contains(risks, m.step.Technique) {
+			risks = append(risks, m.step.Technique)
+		}
+	}
+	return risks
+}
+
+// Paths returns, for every subject named in a binding, the shortest path to
+// each outcome it can reach. Outcomes implied by a broader one, such as the
+// Secrets of a namespace the subject administers, are left out. Paths are
+// ordered by severity, subject and outcome.
+func (e *EscalationAnalysis) Paths() []EscalationPath {
+	paths := make([]EscalationPath, 0)
+	seen := make(map[string]bool)
+	for _, g := range e.resolver.Grants() {
+		name := rbac.SubjectName(g.Subject)
+		// system:masters holds cluster-admin in every cluster
+		if seen[name] || (g.Subject.Kind == rbac.KindGroup && g.Subject.Name == "system:masters") {
+			continue
+		}
+		seen[name] = true
+		paths = append(paths, e.subjectPaths(g.Subject)...)
+	}
+	sortPaths(paths)
+	return paths
+}
+
+// WorkloadAccess is what the service account token of a pod or pod template
+// grants to whoever runs code in its containers
+// | ServiceAccount | Automount | TokenMounted | Grants | Paths |
+type WorkloadAccess struct {
+	ServiceAccount models.Subject
+	// Automount is the automountServiceAccountToken in effect: the pod's,
+	// else the service account's, else true
+	Automount bool
+	// TokenMounted is set when the token is automounted or a projected volume
+	// mounts a token for the API server
+	TokenMounted bool
+	// Grants and Paths of the service account, empty if no token is mounted
+	Grants []rbac.Grant
+	Paths  []EscalationPath
+}
+
+// WorkloadAccess resolves the permissions and escalation paths the service
+// account token mounted into pods of the template grants
+func (e *EscalationAnalysis) WorkloadAccess(namespace string, template models.PodTemplateInfo) WorkloadAccess {
+	name := template.ServiceAccount
+	if name == "" {
+		name = "default"
+	}
+	access := WorkloadAccess{
+		ServiceAccount: models.Subject{Kind: rbac.KindServiceAccount, Namespace: namespace, Name: name},
+		Automount:      true,
+		Grants:         make([]rbac.Grant, 0),
+		Paths:          make([]EscalationPath, 0),
+	}
+	if template.AutomountServiceAccountToken != nil {
+		access.Automount = *template.AutomountServiceAccountToken
+	} else if automount := e.automount[namespace+"/"+name]; automount != nil {
+		access.Automount = *automount
+	}
+	access.TokenMounted = access.Automount
+	for _, v := range template.Volumes {
+		// Tokens for another audience are rejected by the API server
+		if v.ServiceAccountToken && v.TokenAudience == "" && len(v.Mounts) > 0 {
+			access.TokenMounted = true
+		}
+	}
+	if !access.TokenMounted {
+		return access
+	}
+	access.Grants = e.resolver.WhatCan(access.ServiceAccount)
+	access.Paths = e.subjectPaths(access.ServiceAccount)
+	sortPaths(access.Paths)
+	return access
+}
+
+// sortPaths orders paths by severity, subject and outcome
+func sortPaths(paths []EscalationPath) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		a, b := paths[i], paths[j]
+		if a.Severity.Rank() != b.Severity.Rank() {
+			return a.Severity.Rank() < b.Severity.Rank()
+		}
+		if rbac.SubjectName(a.Subject) != rbac.SubjectName(b.Subject) {
+			return rbac.SubjectName(a.Subject) < rbac.SubjectName(b.Subject)
+		}
+		return a.Target() < b.Target()
+	})
+}
+
+// subjectPaths searches breadth first from the subject, so the first path
+// found to an outcome is a shortest one
+func (e *EscalationAnalysis) subjectPaths(subject models.Subject) []EscalationPath {
+	type state struct {
+		identity models.Subject
+		steps    []EscalationStep
+	}
+	reached := make(map[string]EscalationPath)
+	visited := map[string]bool{rbac.SubjectName(subject): true}
+	queue := []state{{identity: subject}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if len(current.steps) >= maxEscalationSteps {
+			continue
+		}
+		for _, g := range e.resolver.WhatCan(current.identity) {
+			for _, m := range e.grantMoves(g) {
+				m.step.Identity = current.identity
+				steps := append(append([]EscalationStep{}, current.steps...), m.step)
+				if m.outcome != "" {
+					key := m.outcome + "/" + m.namespace
+					if _, ok := reached[key]; !ok {
+						reached[key] = EscalationPath{
+							Subject:   subject,
+							Severity:  outcomeSeverity[m.outcome],
+							Outcome:   m.outcome,
+							Namespace: m.namespace,
+							Steps:     steps,
+						}
+					}
+					continue
+				}
+				for _, pivot := range m.pivots {
+					name := rbac.SubjectName(pivot)
+					if visited[name] || len(e.resolver.WhatCan(pivot)) == 0 {
+						continue
+					}
+					visited[name] = true
+					pivotSteps := append([]EscalationStep{}, steps...)
+					pivotSteps[len(pivotSteps)-1].Result = "act as " + name
+					queue = append(queue, state{identity: pivot, steps: pivotSteps})
+				}
+			}
+		}
+	}
+
+	paths := make([]EscalationPath, 0, len(reached))
+	for _, p := range reached {
+		if !impliedOutcome(p, reached) {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// impliedOutcome reports whether another reached outcome already includes p
+func impliedOutcome(p EscalationPath, reached map[string]EscalationPath) bool {
+	if p.Outcome == OutcomeClusterAdmin {
+		return false
+	}
+	if _, ok := reached[OutcomeClusterAdmin+"/"]; ok {
+		return true
+	}
+	if p.Outcome == OutcomeNamespaceSecrets {
+		if _, ok := reached[OutcomeAllSecrets+"/"]; ok {
+			return true
+		}
+		if _, ok := reached[OutcomeNamespaceAdmin+"/"+p.Namespace]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// grantMoves lists the escalation techniques a grant enables. Cluster-scoped
+// resources such as nodes and webhook configurations are only reachable
+// through cluster-wide grants.
+func (e *EscalationAnalysis) grantMoves(g rbac.Grant) []escalationMove {
+	moves := make([]escalationMove, 0)
+	scope := "cluster-wide"
+	if !g.ClusterWide() {
+		scope = "in namespace " + g.Namespace
+	}
+	step := func(technique string) EscalationStep {
+		return EscalationStep{
+			Technique: technique,
+			Scope:     scope,
+			Via:       g.Via(),
+			role:      models.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: g.RoleKind, Name: g.RoleName},
+		}
+	}
+	// adminOutcome is the outcome of a grant that can give itself any
+	// permission within its scope
+	adminOutcome := func(technique string) escalationMove {
+		if g.ClusterWide() {
+			return escalationMove{step: withResult(step(technique), OutcomeClusterAdmin), outcome: OutcomeClusterAdmin}
+		}
+		return escalationMove{
+			step:      withResult(step(technique), OutcomeNamespaceAdmin+" "+g.Namespace),
+			outcome:   OutcomeNamespaceAdmin,
+			namespace: g.Namespace,
+		}
+	}
+	outcome := func(technique, outcome string) escalationMove {
+		return escalationMove{step: withResult(step(technique), outcome), outcome: outcome}
+	}
+	pivot := func(technique string, accounts map[string][]models.Subject) {
+		if targets := e.accountsInScope(g, accounts); len(targets) > 0 {
+			moves = append(moves, escalationMove{step: step(technique), pivots: targets})
+		}
+	}
+	rule := g.Rule
+	privileged := g.ClusterWide() || e.privileged[g.Namespace]
+
+	// A wildcard over another API group, e.g. monitoring.coreos.com, does not
+	// reach RBAC, pods or secrets. Resources checked below without a group
+	// name the core group.
+	coreGroup := contains(rule.APIGroups, "") || contains(rule.APIGroups, "*")
+	if coreGroup && contains(rule.Verbs, "*") && contains(rule.Resources, "*") {
+		moves = append(moves, adminOutcome(techniqueWildcard))
+	}
+	if rbac.Allows(rule, "escalate", "clusterroles.rbac.authorization.k8s.io") || rbac.Allows(rule, "escalate", "roles.rbac.authorization.k8s.io") {
+		moves = append(moves, adminOutcome("escalate roles"))
+	}
+	if rbac.Allows(rule, "bind", "clusterroles.rbac.authorization.k8s.io") || rbac.Allows(rule, "bind", "roles.rbac.authorization.k8s.io") {
+		moves = append(moves, adminOutcome("bind roles"))
+	}
+	if g.ClusterWide() && (rbac.Allows(rule, "impersonate", "users") || rbac.Allows(rule, "impersonate", "groups")) {
+		moves = append(moves, outcome("impersonate users and groups", OutcomeClusterAdmin))
+	}
+	if rbac.Allows(rule, "impersonate", "serviceaccounts") {
+		pivot("impersonate serviceaccounts", e.serviceAccounts)
+	}
+	if rbac.Allows(rule, "create", "pods") {
+		if privileged {
+			moves = append(moves, outcome("create pods", OutcomeNodeCompromise))
+		}
+		pivot("create pods", e.serviceAccounts)
+	}
+	if rbac.Allows(rule, "create", "pods/exec") {
+		if privileged {
+			moves = append(moves, outcome("create pods/exec", OutcomeNodeCompromise))
+		}
+		pivot("create pods/exec", e.podAccounts)
+	}
+	// list and watch return the Secret payloads just like get
+	for _, verb := range []string{"get", "list", "watch"} {
+		if !rbac.Allows(rule, verb, "secrets") {
+			continue
+		}
+		technique := verb + " secrets"
+		if g.ClusterWide() {
+			moves = append(moves, outcome(technique, OutcomeAllSecrets))
+		} else {
+			moves = append(moves, escalationMove{
+				step:      withResult(step(technique), OutcomeNamespaceSecrets+" "+g.Namespace),
+				outcome:   OutcomeNamespaceSecrets,
+				namespace: g.Namespace,
+			})
+		}
+		pivot(technique, e.tokenAccounts)
+		break
+	}
+	if g.ClusterWide() && (rbac.Allows(rule, "get", "nodes/proxy") || rbac.Allows(rule, "create", "nodes/proxy")) {
+		moves = append(moves, outcome("use nodes/proxy", OutcomeNodeCompromise))
+	}
+	if rbac.Allows(rule, "create", "serviceaccounts/token") {
+		pivot("create serviceaccounts/token", e.serviceAccounts)
+	}
+	if g.ClusterWide() {
+		for _, verb := range []string{"update", "patch"} {
+			if rbac.Allows(rule, verb, "mutatingwebhookconfigurations.admissionregistration.k8s.io") {
+				moves = append(moves, outcome("update webhook configurations", OutcomeMutateRequests))
+				break
+			}
+		}
+		for _, verb := range []string{"update", "patch"} {
+			if rbac.Allows(rule, verb, "validatingwebhookconfigurations.admissionregistration.k8s.io") {
+				moves = append(moves, outcome("update webhook configurations", OutcomeInterceptRequests))
+				break
+			}
+		}
+	}
+	return moves
+}
+
+// accountsInScope returns the service accounts a grant reaches: those of its
+// namespace, or of every namespace for cluster-wide grants
+func (e *EscalationAnalysis) accountsInScope(g rbac.Grant, accounts map[string][]models.Subject) []models.Subject {
+	if !g.ClusterWide() {
+		return accounts[g.Namespace]
+	}
+	namespaces := make([]string, 0, len(accounts))
+	for ns := range accounts {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	targets := make([]models.Subject, 0)
+	for _, ns := range namespaces {
+		targets = append(targets, accounts[ns]...)
+	}
+	return targets
+}
+
+func withResult(step EscalationStep, result string) EscalationStep {
+	step.Result = result
+	return step
+}
+
+// checkEscalationPaths reports the escalation paths that are not already
+// covered by the cluster-admin binding findings, i.e. all but direct
+// bindings to cluster-admin. Direct grants of custom wildcard roles are
+// reported here.
+func checkEscalationPaths(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	for _, p := range NewEscalationAnalysis(data).Paths() {
+		if len(p.Steps) == 1 && p.Steps[0].Technique == techniqueWildcard && isClusterAdmin(p.Steps[0].role) {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:          "KR-RBAC-006",
+			Severity:    p.Severity,
+			Category:    "RBAC",
+			Kind:        p.Subject.Kind,
+			Namespace:   p.Subject.Namespace,
+			Name:        p.Subject.Name,
+			Title:       fmt.Sprintf("Privilege escalation path to %s", p.Target()),
+			Detail:      p.Describe(),
+			Remediation: "Remove the grant used by the first step, or scope it to the resources and namespaces the subject needs.",
+		})
+	}
+	return findings
+}
+
+// checkExposedTokens flags pods reachable from outside the cluster that mount
+// the token of a service account with an escalation path. Whoever exploits
+// the exposed application can use the token right away.
+func checkExposedTokens(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	exposure := graph.NewExposure(data)
+	escalation := NewEscalationAnalysis(data)
+	for _, pod := range data.Workloads.Pods {
+		exposed := exposure.Pod(pod)
+		if len(exposed) == 0 {
+			continue
+		}
+		access := escalation.WorkloadAccess(pod.Namespace, pod.PodTemplateInfo)
+		if len(access.Paths) == 0 {
+			continue
+		}
+		targets := make([]string, 0, len(access.Paths))
+		for _, p := range access.Paths {
+			targets = append(targets, p.Target())
+		}
+		findings = append(findings, Finding{
+			ID:          "KR-RBAC-010",
+			Severity:    access.Paths[0].Severity,
+			Category:    "RBAC",
+			Kind:        "Pod",
+			Namespace:   pod.Namespace,
+			Name:        pod.Name,
+			Title:       "Internet-facing pod mounts a privileged service account token",
+			Detail:      fmt.Sprintf("Reachable through %s; the token of %s can reach: %s", strings.Join(exposed, ", "), rbac.SubjectName(access.ServiceAccount), strings.Join(targets, ", ")),
+			Remediation: "Set automountServiceAccountToken: false and remove projected token volumes unless the application calls the API server, or run it under a service account without these grants.",
+		})
+	}
+	return findings
+}
@@ -0,0 +1,210 @@
+package rules
+
+import (
+	"testing"
+
+	"kubeRadar/pkg/models"
+)
+
+// escalationData binds a single rule to User dev, either cluster-wide or in
+// the given namespace, next to a ServiceAccount ci/deployer that holds a
+// cluster-wide wildcard and runs in a pod of namespace ci
+func escalationData(namespace string, rule models.PolicyRule) *models.AssessmentData {
+	data := &models.AssessmentData{}
+	data.ClusterInfo.Namespaces = []models.NamespaceInfo{
+		{Name: "ci"},
+		{Name: "tools", Labels: map[string]string{privilegedNamespaceLabel: "privileged"}},
+	}
+	data.RBAC.ServiceAccounts = []models.ServiceAccountInfo{{Name: "deployer", Namespace: "ci"}}
+	data.RBAC.ClusterRoles = []models.RoleInfo{
+		{Name: "tested", ClusterRole: true, Rules: []models.PolicyRule{rule}},
+		{Name: "admin-all", ClusterRole: true, Rules: []models.PolicyRule{
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+		}},
+	}
+	data.RBAC.ClusterRoleBindings = []models.BindingInfo{
+		{Name: "deployer", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "admin-all"}, Subjects: []models.Subject{{Kind: "ServiceAccount", Namespace: "ci", Name: "deployer"}}},
+	}
+	binding := models.BindingInfo{Name: "dev", Namespace: namespace, RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "tested"}, Subjects: []models.Subject{{Kind: "User", Name: "dev"}}}
+	if namespace == "" {
+		data.RBAC.ClusterRoleBindings = append(data.RBAC.ClusterRoleBindings, binding)
+	} else {
+		data.RBAC.RoleBindings = []models.BindingInfo{binding}
+	}
+	data.Workloads.Pods = []models.PodInfo{{Name: "runner", Namespace: "ci", PodTemplateInfo: models.PodTemplateInfo{ServiceAccount: "deployer"}}}
+	return data
+}
+
+func TestEscalationPaths(t *testing.T) {
+	rule := func(group, resource string, verbs ...string) models.PolicyRule {
+		return models.PolicyRule{APIGroups: []string{group}, Resources: []string{resource}, Verbs: verbs}
+	}
+	tests := []struct {
+		name      string
+		namespace string
+		rule      models.PolicyRule
+		// outcomes of User dev, with the number of steps to reach them
+		want map[string]int
+	}{
+		{"escalate", "", rule("rbac.authorization.k8s.io", "clusterroles", "escalate", "update"), map[string]int{"cluster-admin": 1}},
+		{"bind in namespace", "ci", rule("rbac.authorization.k8s.io", "roles", "bind"), map[string]int{"admin of namespace ci": 1}},
+		{"impersonate groups", "", rule("", "groups", "impersonate"), map[string]int{"cluster-admin": 1}},
+		{"impersonate users in namespace", "ci", rule("", "users", "impersonate"), map[string]int{}},
+		{"create pods", "ci", rule("", "pods", "create"), map[string]int{"cluster-admin": 2}},
+		{"create pods in privileged namespace", "tools", rule("", "pods", "create"), map[string]int{"node compromise": 1}},
+		{"exec into pods", "ci", rule("", "pods/exec", "create"), map[string]int{"cluster-admin": 2}},
+		{"get secrets cluster-wide", "", rule("", "secrets", "get"), map[string]int{"read every Secret": 1}},
+		{"get secrets in namespace", "ci", rule("", "secrets", "get"), map[string]int{"read Secrets of namespace ci": 1}},
+		{"list secrets cluster-wide", "", rule("", "secrets", "list"), map[string]int{"read every Secret": 1}},
+		{"watch secrets in namespace", "ci", rule("", "secrets", "watch"), map[string]int{"read Secrets of namespace ci": 1}},
+		{"nodes/proxy", "", rule("", "nodes/proxy", "get"), map[string]int{"node compromise": 1}},
+		{"nodes/proxy in namespace", "ci", rule("", "nodes/proxy", "get"), map[string]int{}},
+		{"mint tokens", "ci", rule("", "serviceaccounts/token", "create"), map[string]int{"cluster-admin": 2}},
+		{"mutating webhooks", "", rule("admissionregistration.k8s.io", "mutatingwebhookconfigurations", "patch"), map[string]int{"mutate API requests": 1}},
+		{"validating webhooks", "", rule("admissionregistration.k8s.io", "validatingwebhookconfigurations", "update"), map[string]int{"intercept API requests": 1}},
+		{"read-only", "", rule("", "pods", "get", "list"), map[string]int{}},
+		{"wildcard", "", rule("*", "*", "*"), map[string]int{"cluster-admin": 1}},
+		{"wildcard of another group", "", rule("monitoring.coreos.com", "*", "*"), map[string]int{}},
+		{"wildcard of another group in namespace", "ci", rule("velero.io", "*", "*"), map[string]int{}},
+		{"read another group", "", rule("apps", "*", "get", "list"), map[string]int{}},
+		{"create in another group", "tools", rule("apps", "*", "create"), map[string]int{}},
+	}
+	for _, tt := range tests {
+		got := make(map[string]int)
+		for _, p := range NewEscalationAnalysis(escalationData(tt.namespace, tt.rule)).Paths() {
+			if p.Subject.Name == "dev" {
+				got[p.Target()] = len(p.Steps)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: paths = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for target, steps := range tt.want {
+			if got[target] != steps {
+				t.Errorf("%s: paths = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestEscalationPathsListSecretsPivotsToTokens(t *testing.T) {
+	data := escalationData("ci", models.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"list"}})
+	data.RBAC.ServiceAccounts[0].Secrets = []string{"deployer-token"}
+	for _, p := range NewEscalationAnalysis(data).Paths() {
+		if p.Subject.Name != "dev" || p.Target() != OutcomeClusterAdmin {
+			continue
+		}
+		if len(p.Steps) != 2 || p.Steps[0].Technique != "list secrets" {
+			t.Errorf("unexpected path to cluster-admin: %s", p.Describe())
+		}
+		return
+	}
+	t.Error("list secrets does not pivot to the token of ci/deployer")
+}
+
+func TestCheckEscalationPathsWildcardRoles(t *testing.T) {
+	// dev is bound to cluster-admin, which KR-RBAC-003 already reports, and
+	// ci/deployer to the custom wildcard role admin-all
+	data := escalationData("", models.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}})
+	data.RBAC.ClusterRoleBindings[1].RoleRef.Name = "cluster-admin"
+	data.RBAC.ClusterRoles[0].Name = "cluster-admin"
+
+	got := make([]string, 0)
+	for _, f := range checkEscalationPaths(data) {
+		got = append(got, f.Namespace+"/"+f.Name+": "+f.Title)
+	}
+	want := "ci/deployer: Privilege escalation path to cluster-admin"
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("escalation findings = %v, want [%s]", got, want)
+	}
+}
+
+func TestEscalationRisks(t *testing.T) {
+	data := escalationData("", models.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods", "secrets"}, Verbs: []string{"create", "get"}})
+	e := NewEscalationAnalysis(data)
+	for _, g := range e.resolver.Grants() {
+		risks := e.Risks(g)
+		switch g.Subject.Name {
+		case "dev":
+			if len(risks) != 2 || risks[0] != "create pods" || risks[1] != "get secrets" {
+				t.Errorf("Risks of dev = %v, want [create pods get secrets]", risks)
+			}
+		case "deployer":
+			if len(risks) != 1 || risks[0] != techniqueWildcard {
+				t.Errorf("Risks of deployer = %v, want [%s]", risks, techniqueWildcard)
+			}
+		}
+	}
+}
+
+func TestWorkloadAccess(t *testing.T) {
+	data := escalationData("", models.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}})
+	data.RBAC.ServiceAccounts = append(data.RBAC.ServiceAccounts,
+		models.ServiceAccountInfo{Name: "quiet", Namespace: "ci", AutomountServiceAccountToken: ptr(false)})
+	data.RBAC.ClusterRoleBindings = append(data.RBAC.ClusterRoleBindings, models.BindingInfo{
+		Name: "quiet", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "tested"},
+		Subjects: []models.Subject{{Kind: "ServiceAccount", Namespace: "ci", Name: "quiet"}},
+	})
+	e := NewEscalationAnalysis(data)
+	apiToken := models.VolumeInfo{Name: "token", ServiceAccountToken: true, Mounts: []models.VolumeMountInfo{{Container: "app"}}}
+	vaultToken := apiToken
+	vaultToken.TokenAudience = "vault"
+
+	tests := []struct {
+		name     string
+		template models.PodTemplateInfo
+		// automount in effect, whether a token is mounted and the first target
+		automount, mounted bool
+		target             string
+	}{
+		{"default", models.PodTemplateInfo{ServiceAccount: "deployer"}, true, true, OutcomeClusterAdmin},
+		{"pod opts out", models.PodTemplateInfo{ServiceAccount: "deployer", AutomountServiceAccountToken: ptr(false)}, false, false, ""},
+		{"service account opts out", models.PodTemplateInfo{ServiceAccount: "quiet"}, false, false, ""},
+		{"pod overrides service account", models.PodTemplateInfo{ServiceAccount: "quiet", AutomountServiceAccountToken: ptr(true)}, true, true, OutcomeAllSecrets},
+		{"projected token", models.PodTemplateInfo{ServiceAccount: "quiet", Volumes: []models.VolumeInfo{apiToken}}, false, true, OutcomeAllSecrets},
+		{"projected token for another audience", models.PodTemplateInfo{ServiceAccount: "quiet", Volumes: []models.VolumeInfo{vaultToken}}, false, false, ""},
+		{"default service account", models.PodTemplateInfo{}, true, true, ""},
+	}
+	for _, tt := range tests {
+		access := e.WorkloadAccess("ci", tt.template)
+		if access.Automount != tt.automount || access.TokenMounted != tt.mounted {
+			t.Errorf("%s: automount %v, mounted %v, want %v, %v", tt.name, access.Automount, access.TokenMounted, tt.automount, tt.mounted)
+		}
+		target := ""
+		if len(access.Paths) > 0 {
+			target = access.Paths[0].Target()
+		}
+		if target != tt.target {
+			t.Errorf("%s: first target %q, want %q", tt.name, target, tt.target)
+		}
+		if !tt.mounted && len(access.Grants) > 0 {
+			t.Errorf("%s: grants %+v without a mounted token", tt.name, access.Grants)
+		}
+	}
+}
+
+func TestCheckExposedTokens(t *testing.T) {
+	data := escalationData("", models.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}})
+	data.Workloads.Pods = []models.PodInfo{
+		{Name: "runner", Namespace: "ci", Labels: map[string]string{"app": "runner"}, PodTemplateInfo: models.PodTemplateInfo{ServiceAccount: "deployer"}},
+		{Name: "internal", Namespace: "ci", Labels: map[string]string{"app": "internal"}, PodTemplateInfo: models.PodTemplateInfo{ServiceAccount: "deployer"}},
+		{Name: "no-token", Namespace: "ci", Labels: map[string]string{"app": "runner"}, PodTemplateInfo: models.PodTemplateInfo{ServiceAccount: "deployer", AutomountServiceAccountToken: ptr(false)}},
+	}
+	data.Network.Services = []models.ServiceInfo{
+		{Name: "runner", Namespace: "ci", Type: "NodePort", Selector: map[string]string{"app": "runner"}},
+		{Name: "internal", Namespace: "ci", Type: "ClusterIP", Selector: map[string]string{"app": "internal"}},
+	}
+	findings := checkExposedTokens(data)
+	if len(findings) != 1 {
+		t.Fatalf("checkExposedTokens returned %d findings, want 1: %+v", len(findings), findings)
+	}
+	if f := findings[0]; f.Name != "runner" || f.Severity != SeverityCritical {
+		t.Errorf("finding = %+v, want a Critical finding for pod runner", f)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
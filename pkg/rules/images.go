@@ -0,0 +1,33 @@
+package rules
+
+import (
+	"strings"
+
+	"kubeRadar/pkg/models"
+)
+
+// mutableImage reports whether an image reference is pinned neither by
+// digest nor by a specific tag, i.e. it is untagged or uses latest
+func mutableImage(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+	// The tag follows the last colon after the last slash; a colon before
+	// it belongs to the registry port
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, found := strings.Cut(name, ":")
+	return !found || tag == "latest"
+}
+
+// EffectivePullPolicy returns the image pull policy of a container, applying
+// the API server default when it is unset: Always for untagged and latest
+// images, IfNotPresent otherwise
+func EffectivePullPolicy(c models.ContainerInfo) string {
+	if c.ImagePullPolicy != "" {
+		return c.ImagePullPolicy
+	}
+	if mutableImage(c.Image) {
+		return "Always"
+	}
+	return "IfNotPresent"
+}
@@ -0,0 +1,20 @@
+package rules
+
+import "testing"
+
+func TestMutableImage(t *testing.T) {
+	tests := map[string]bool{
+		"busybox":                           true,
+		"busybox:latest":                    true,
+		"registry.example.com:5000/app":     true,
+		"registry.example.com:5000/app:1.2": false,
+		"nginx:1.27":                        false,
+		"nginx@sha256:0123abcd":             false,
+		"nginx:latest@sha256:0123abcd":      false,
+	}
+	for image, want := range tests {
+		if got := mutableImage(image); got != want {
+			t.Errorf("mutableImage(%q) = %v, want %v", image, got, want)
+		}
+	}
+}
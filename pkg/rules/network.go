@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeRadar/pkg/models"
+)
+
+func checkNetworkPolicies(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+
+	covered := make(map[string]bool)
+	for _, np := range data.Network.NetworkPolicies {
+		covered[np.Namespace] = true
+	}
+	podCount := make(map[string]int)
+	for _, pod := range data.Workloads.Pods {
+		podCount[pod.Namespace]++
+	}
+
+	for _, ns := range data.ClusterInfo.Namespaces {
+		if covered[ns.Name] || podCount[ns.Name] == 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:          "KR-NET-001",
+			Severity:    SeverityMedium,
+			Category:    "Network",
+			Kind:        "Namespace",
+			Name:        ns.Name,
+			Title:       "Namespace without NetworkPolicy",
+			Detail:      fmt.Sprintf("%d pods accept traffic from any pod in the cluster", podCount[ns.Name]),
+			Remediation: "Add a default-deny NetworkPolicy and explicitly allow the required traffic.",
+		})
+	}
+	return findings
+}
+
+func checkServiceExposure(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	for _, svc := range data.Network.Services {
+		switch svc.Type {
+		case "LoadBalancer":
+			findings = append(findings, Finding{
+				ID:          "KR-NET-002",
+				Severity:    SeverityMedium,
+				Category:    "Network",
+				Kind:        "Service",
+				Namespace:   svc.Namespace,
+				Name:        svc.Name,
+				Title:       "Service exposed through a load balancer",
+				Detail:      "The service is reachable from outside the cluster network",
+				Remediation: "Confirm the exposure is intended and restrict loadBalancerSourceRanges where possible.",
+			})
+		case "NodePort":
+			findings = append(findings, Finding{
+				ID:          "KR-NET-003",
+				Severity:    SeverityLow,
+				Category:    "Network",
+				Kind:        "Service",
+				Namespace:   svc.Namespace,
+				Name:        svc.Name,
+				Title:       "Service exposed on node ports",
+				Detail:      "The service listens on every node's IP address",
+				Remediation: "Use a ClusterIP service behind an Ingress, or firewall the node port range.",
+			})
+		}
+		if len(svc.ExternalIPs) > 0 {
+			findings = append(findings, Finding{
+				ID:          "KR-NET-004",
+				Severity:    SeverityMedium,
+				Category:    "Network",
+				Kind:        "Service",
+				Namespace:   svc.Namespace,
+				Name:        svc.Name,
+				Title:       "Service uses externalIPs",
+				Detail:      fmt.Sprintf("External IPs: %s", strings.Join(svc.ExternalIPs, ", ")),
+				Remediation: "Avoid externalIPs (CVE-2020-8554) and restrict them with an admission policy.",
+			})
+		}
+	}
+	return findings
+}
+
+func checkIngressTLS(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	for _, ing := range data.Network.Ingresses {
+		if len(ing.TLS) > 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:          "KR-NET-005",
+			Severity:    SeverityMedium,
+			Category:    "Network",
+			Kind:        "Ingress",
+			Namespace:   ing.Namespace,
+			Name:        ing.Name,
+			Title:       "Ingress without TLS",
+			Detail:      "Traffic to the ingress hosts is served over plain HTTP",
+			Remediation: "Add a tls section referencing a certificate for every host.",
+		})
+	}
+	return findings
+}
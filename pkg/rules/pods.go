@@ -0,0 +1,246 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeRadar/pkg/models"
+)
+
+// dangerousCapabilities are Linux capabilities that allow breaking out of
+// the container or tampering with the node
+var dangerousCapabilities = map[string]bool{
+	"ALL":             true,
+	"SYS_ADMIN":       true,
+	"SYS_PTRACE":      true,
+	"SYS_MODULE":      true,
+	"SYS_RAWIO":       true,
+	"NET_ADMIN":       true,
+	"DAC_READ_SEARCH": true,
+	"BPF":             true,
+}
+
+func checkPodSecurity(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	for _, pod := range data.Workloads.Pods {
+		findings = append(findings, podSecurityFindings("Pod", pod.Namespace, pod.Name, pod.PodTemplateInfo)...)
+	}
+	// Batch pods only exist while they run, so the templates of CronJobs and
+	// standalone Jobs are checked too. Jobs created by a CronJob are covered
+	// by the CronJob.
+	for _, cj := range data.Workloads.CronJobs {
+		findings = append(findings, podSecurityFindings("CronJob", cj.Namespace, cj.Name, cj.Template)...)
+	}
+	for _, job := range data.Workloads.Jobs {
+		if job.Owner != "" {
+			continue
+		}
+		findings = append(findings, podSecurityFindings("Job", job.Namespace, job.Name, job.Template)...)
+	}
+	return findings
+}
+
+// podSecurityFindings checks the security settings of a pod or pod template
+func podSecurityFindings(kind, namespace, name string, template models.PodTemplateInfo) []Finding {
+	findings := make([]Finding, 0)
+	podSecurity := template.SecurityContext
+	newFinding := func(id string, sev Severity, title, detail, remediation string) Finding {
+		return Finding{
+			ID:          id,
+			Severity:    sev,
+			Category:    "Pod Security",
+			Kind:        kind,
+			Namespace:   namespace,
+			Name:        name,
+			Title:       title,
+			Detail:      detail,
+			Remediation: remediation,
+		}
+	}
+
+	privileged := make([]string, 0)
+	rootUser := make([]string, 0)
+	escalation := make([]string, 0)
+	capabilities := make([]string, 0)
+	noLimits := make([]string, 0)
+	ephemeral := make([]string, 0)
+	unconfinedSeccomp := make([]string, 0)
+	unconfinedAppArmor := make([]string, 0)
+	unmaskedProc := make([]string, 0)
+	hostPorts := make([]string, 0)
+	stalePull := make([]string, 0)
+	for _, c := range template.Containers {
+		name := containerName(c)
+		sc := c.SecurityContext
+		if sc.Privileged {
+			privileged = append(privileged, name)
+		}
+		if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+			rootUser = append(rootUser, name)
+		}
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			escalation = append(escalation, name)
+		}
+		for _, cap := range sc.Capabilities {
+			if strings.HasPrefix(cap, "+") && dangerousCapabilities[strings.TrimPrefix(cap, "+")] {
+				capabilities = append(capabilities, fmt.Sprintf("%s: %s", name, strings.TrimPrefix(cap, "+")))
+			}
+		}
+		if EffectiveSeccompProfile(podSecurity, sc) == "Unconfined" {
+			unconfinedSeccomp = append(unconfinedSeccomp, name)
+		}
+		if EffectiveAppArmorProfile(podSecurity, sc) == "Unconfined" {
+			unconfinedAppArmor = append(unconfinedAppArmor, name)
+		}
+		if sc.ProcMount == "Unmasked" {
+			unmaskedProc = append(unmaskedProc, name)
+		}
+		for _, p := range c.Ports {
+			if p.HostPort != 0 {
+				hostPorts = append(hostPorts, fmt.Sprintf("%s: %d/%s", name, p.HostPort, p.Protocol))
+			}
+		}
+		if mutableImage(c.Image) && EffectivePullPolicy(c) != "Always" {
+			stalePull = append(stalePull, fmt.Sprintf("%s: %s (%s)", name, c.Image, EffectivePullPolicy(c)))
+		}
+		// Ephemeral containers cannot declare resources
+		if c.Type == models.ContainerTypeEphemeral {
+			ephemeral = append(ephemeral, fmt.Sprintf("%s (%s)", c.Name, c.Image))
+		} else if missing := missingLimits(c.Resources.Limits); missing != "" {
+			noLimits = append(noLimits, fmt.Sprintf("%s (%s)", name, missing))
+		}
+	}
+
+	if len(privileged) > 0 {
+		findings = append(findings, newFinding("KR-POD-001", SeverityCritical,
+			"Privileged container",
+			fmt.Sprintf("Containers running privileged: %s", strings.Join(privileged, ", ")),
+			"Remove securityContext.privileged or set it to false; grant only the specific capabilities the workload needs."))
+	}
+	if len(ephemeral) > 0 {
+		findings = append(findings, newFinding("KR-POD-009", SeverityHigh,
+			"Ephemeral debug container attached",
+			fmt.Sprintf("Ephemeral containers: %s", strings.Join(ephemeral, ", ")),
+			"Find out who attached the container with kubectl debug and why, then recreate the pod to remove it and restrict the pods/ephemeralcontainers subresource."))
+	}
+	findings = append(findings, hostPathFindings(newFinding, template.Volumes)...)
+	findings = append(findings, sysctlFindings(newFinding, podSecurity.Sysctls)...)
+	if podSecurity.HostPID {
+		findings = append(findings, newFinding("KR-POD-002", SeverityHigh,
+			"Pod shares the host PID namespace",
+			"hostPID is enabled, so processes on the node are visible and can be signalled from the pod",
+			"Set spec.hostPID to false unless the pod is a trusted node agent."))
+	}
+	if podSecurity.HostNetwork {
+		findings = append(findings, newFinding("KR-POD-003", SeverityHigh,
+			"Pod shares the host network namespace",
+			"hostNetwork is enabled, so the pod bypasses NetworkPolicies and can bind to node interfaces",
+			"Set spec.hostNetwork to false and expose the workload through a Service instead."))
+	}
+	if podSecurity.HostIPC {
+		findings = append(findings, newFinding("KR-POD-004", SeverityHigh,
+			"Pod shares the host IPC namespace",
+			"hostIPC is enabled, so shared memory of other processes on the node is reachable",
+			"Set spec.hostIPC to false."))
+	}
+	if len(capabilities) > 0 {
+		findings = append(findings, newFinding("KR-POD-005", SeverityHigh,
+			"Dangerous Linux capabilities added",
+			fmt.Sprintf("Added capabilities: %s", strings.Join(capabilities, ", ")),
+			"Drop ALL capabilities and add back only those the workload strictly requires."))
+	}
+	if len(rootUser) > 0 {
+		findings = append(findings, newFinding("KR-POD-006", SeverityMedium,
+			"Container runs as root",
+			fmt.Sprintf("Containers with runAsUser 0: %s", strings.Join(rootUser, ", ")),
+			"Run the container as a non-zero UID and set runAsNonRoot to true."))
+	}
+	if len(escalation) > 0 {
+		findings = append(findings, newFinding("KR-POD-007", SeverityLow,
+			"Privilege escalation not disabled",
+			fmt.Sprintf("Containers without allowPrivilegeEscalation: false: %s", strings.Join(escalation, ", ")),
+			"Set securityContext.allowPrivilegeEscalation to false on every container."))
+	}
+	if len(hostPorts) > 0 {
+		findings = append(findings, newFinding("KR-POD-016", SeverityMedium,
+			"Container binds a host port",
+			fmt.Sprintf("Host ports: %s", strings.Join(hostPorts, ", ")),
+			"Expose the container through a Service instead; hostPorts are reachable on the node IP and bypass NetworkPolicies."))
+	}
+	if len(unmaskedProc) > 0 {
+		findings = append(findings, newFinding("KR-POD-013", SeverityHigh,
+			"Unmasked /proc mount",
+			fmt.Sprintf("Containers with procMount Unmasked: %s", strings.Join(unmaskedProc, ", ")),
+			"Remove securityContext.procMount or set it to Default."))
+	}
+	if len(unconfinedAppArmor) > 0 {
+		findings = append(findings, newFinding("KR-POD-014", SeverityMedium,
+			"AppArmor disabled",
+			fmt.Sprintf("Containers running with an Unconfined AppArmor profile: %s", strings.Join(unconfinedAppArmor, ", ")),
+			"Set appArmorProfile.type to RuntimeDefault or a Localhost profile."))
+	}
+	if len(unconfinedSeccomp) > 0 {
+		findings = append(findings, newFinding("KR-POD-012", SeverityLow,
+			"Seccomp profile not enforced",
+			fmt.Sprintf("Containers running Unconfined, which is the default when no profile is set: %s", strings.Join(unconfinedSeccomp, ", ")),
+			"Set securityContext.seccompProfile.type to RuntimeDefault at pod level."))
+	}
+	if len(stalePull) > 0 {
+		findings = append(findings, newFinding("KR-POD-017", SeverityLow,
+			"Mutable image tag not pulled on start",
+			fmt.Sprintf("Untagged or latest images with a cached pull policy: %s", strings.Join(stalePull, ", ")),
+			"Pin images by digest or a release tag, or set imagePullPolicy to Always."))
+	}
+	if len(noLimits) > 0 {
+		findings = append(findings, newFinding("KR-POD-008", SeverityLow,
+			"Container without resource limits",
+			fmt.Sprintf("Containers missing CPU or memory limits: %s", strings.Join(noLimits, ", ")),
+			"Define CPU and memory limits, or enforce defaults with a LimitRange."))
+	}
+	return findings
+}
+
+// missingLimits names the CPU and memory limits a container does not set,
+// e.g. "no memory limit", or returns an empty string when both are set
+func missingLimits(limits models.ResourceList) string {
+	switch {
+	case limits.CPU == "0" && limits.Memory == "0":
+		return "no CPU and memory limits"
+	case limits.CPU == "0":
+		return "no CPU limit"
+	case limits.Memory == "0":
+		return "no memory limit"
+	}
+	return ""
+}
+
+// containerName returns the container name, annotated with its type unless
+// it is a regular container
+func containerName(c models.ContainerInfo) string {
+	if c.Type == "" || c.Type == models.ContainerTypeRegular {
+		return c.Name
+	}
+	return fmt.Sprintf("%s (%s)", c.Name, c.Type)
+}
+
+// EffectiveSeccompProfile returns the seccomp profile a container runs with.
+// The container setting overrides the pod setting, and containers without
+// either run Unconfined.
+func EffectiveSeccompProfile(pod models.PodSecurityInfo, c models.ContainerSecurityInfo) string {
+	if c.SeccompProfile != "" {
+		return c.SeccompProfile
+	}
+	if pod.SeccompProfile != "" {
+		return pod.SeccompProfile
+	}
+	return "Unconfined"
+}
+
+// EffectiveAppArmorProfile returns the AppArmor profile set for a container,
+// or an empty string when the runtime default applies
+func EffectiveAppArmorProfile(pod models.PodSecurityInfo, c models.ContainerSecurityInfo) string {
+	if c.AppArmorProfile != "" {
+		return c.AppArmorProfile
+	}
+	return pod.AppArmorProfile
+}
@@ -0,0 +1,36 @@
+package rules
+
+import (
+	"testing"
+
+	"kubeRadar/pkg/models"
+)
+
+func TestPodSecurityFindingsResourceLimits(t *testing.T) {
+	container := func(name, cpu, memory string) models.ContainerInfo {
+		return models.ContainerInfo{Name: name, Type: models.ContainerTypeRegular, Resources: models.ResourceRequirements{
+			Limits: models.ResourceList{CPU: cpu, Memory: memory},
+		}}
+	}
+	tests := []struct {
+		containers []models.ContainerInfo
+		want       string // Detail of KR-POD-008, empty if not raised
+	}{
+		{[]models.ContainerInfo{container("api", "500m", "256Mi")}, ""},
+		{[]models.ContainerInfo{container("api", "0", "256Mi")}, "Containers missing CPU or memory limits: api (no CPU limit)"},
+		{[]models.ContainerInfo{container("api", "500m", "0")}, "Containers missing CPU or memory limits: api (no memory limit)"},
+		{[]models.ContainerInfo{container("api", "0", "0"), container("proxy", "100m", "0")},
+			"Containers missing CPU or memory limits: api (no CPU and memory limits), proxy (no memory limit)"},
+	}
+	for _, tt := range tests {
+		got := ""
+		for _, f := range podSecurityFindings("Pod", "payments", "api", models.PodTemplateInfo{Containers: tt.containers}) {
+			if f.ID == "KR-POD-008" {
+				got = f.Detail
+			}
+		}
+		if got != tt.want {
+			t.Errorf("KR-POD-008 detail = %q, want %q", got, tt.want)
+		}
+	}
+}
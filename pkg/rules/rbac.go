@@ -0,0 +1,304 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rbac"
+)
+
+func checkRBACRules(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	roles := make([]models.RoleInfo, 0, len(data.RBAC.ClusterRoles)+len(data.RBAC.Roles))
+	roles = append(roles, data.RBAC.ClusterRoles...)
+	roles = append(roles, data.RBAC.Roles...)
+
+	for _, role := range roles {
+		// cluster-admin is wildcard by definition, its bindings are checked instead
+		if role.ClusterRole && role.Name == "cluster-admin" {
+			continue
+		}
+		kind := "Role"
+		if role.ClusterRole {
+			kind = "ClusterRole"
+		}
+
+		wildcardVerbs := false
+		wildcardResources := false
+		for _, rule := range role.Rules {
+			if contains(rule.Verbs, "*") {
+				wildcardVerbs = true
+			}
+			if contains(rule.Resources, "*") {
+				wildcardResources = true
+			}
+		}
+
+		if wildcardVerbs {
+			findings = append(findings, Finding{
+				ID:          "KR-RBAC-001",
+				Severity:    SeverityHigh,
+				Category:    "RBAC",
+				Kind:        kind,
+				Namespace:   role.Namespace,
+				Name:        role.Name,
+				Title:       "Wildcard verbs in role",
+				Detail:      "At least one rule grants every verb (*), including future verbs such as escalate and impersonate",
+				Remediation: "List the verbs the subject needs explicitly instead of using *.",
+			})
+		}
+		if wildcardResources {
+			findings = append(findings, Finding{
+				ID:          "KR-RBAC-002",
+				Severity:    SeverityMedium,
+				Category:    "RBAC",
+				Kind:        kind,
+				Namespace:   role.Namespace,
+				Name:        role.Name,
+				Title:       "Wildcard resources in role",
+				Detail:      "At least one rule applies to every resource (*), including secrets and any CRDs installed later",
+				Remediation: "List the resources the subject needs explicitly instead of using *.",
+			})
+		}
+	}
+	return findings
+}
+
+func checkRBACBindings(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+
+	for _, crb := range data.RBAC.ClusterRoleBindings {
+		findings = append(findings, anonymousBindingFindings("ClusterRoleBinding", crb)...)
+		if !isClusterAdmin(crb.RoleRef) {
+			continue
+		}
+		subjects := make([]string, 0)
+		for _, s := range crb.Subjects {
+			// system:masters is bound to cluster-admin in every cluster
+			if s.Kind == "Group" && s.Name == "system:masters" {
+				continue
+			}
+			subjects = append(subjects, formatSubject(s))
+		}
+		if len(subjects) == 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:          "KR-RBAC-003",
+			Severity:    SeverityCritical,
+			Category:    "RBAC",
+			Kind:        "ClusterRoleBinding",
+			Name:        crb.Name,
+			Title:       "cluster-admin granted cluster-wide",
+			Detail:      fmt.Sprintf("Subjects with full control of the cluster: %s", strings.Join(subjects, ", ")),
+			Remediation: "Replace the binding with a role scoped to the permissions the subjects actually need.",
+		})
+	}
+
+	for _, rb := range data.RBAC.RoleBindings {
+		findings = append(findings, anonymousBindingFindings("RoleBinding", rb)...)
+		if !isClusterAdmin(rb.RoleRef) {
+			continue
+		}
+		subjects := make([]string, 0)
+		for _, s := range rb.Subjects {
+			subjects = append(subjects, formatSubject(s))
+		}
+		findings = append(findings, Finding{
+			ID:          "KR-RBAC-004",
+			Severity:    SeverityHigh,
+			Category:    "RBAC",
+			Kind:        "RoleBinding",
+			Namespace:   rb.Namespace,
+			Name:        rb.Name,
+			Title:       "cluster-admin granted in namespace",
+			Detail:      fmt.Sprintf("Subjects with full control of namespace %s: %s", rb.Namespace, strings.Join(subjects, ", ")),
+			Remediation: "Bind the built-in admin or edit ClusterRole, or a custom Role, instead of cluster-admin.",
+		})
+	}
+	return findings
+}
+
+// publicClusterRoles are the default ClusterRoles Kubernetes binds to
+// unauthenticated users: version and health endpoints, and API discovery in
+// clusters created before 1.14. The API server reconciles their rules.
+// system:basic-user is left out, Kubernetes stopped binding it to
+// unauthenticated users in 1.14.
+var publicClusterRoles = map[string]bool{
+	"system:public-info-viewer": true,
+	"system:discovery":          true,
+}
+
+// IsPublicGrant reports whether the grant comes from a default public
+// ClusterRole, which only allows reading version, health and discovery
+// endpoints and is held by every client
+func IsPublicGrant(g rbac.Grant) bool {
+	return g.RoleKind == "ClusterRole" && publicClusterRoles[g.RoleName] && len(g.Rule.Resources) == 0
+}
+
+// anonymousBindingFindings flags bindings that grant access to unauthenticated
+// users, except those of the default public ClusterRoles
+func anonymousBindingFindings(kind string, binding models.BindingInfo) []Finding {
+	findings := make([]Finding, 0)
+	if binding.RoleRef.Kind == "ClusterRole" && publicClusterRoles[binding.RoleRef.Name] {
+		return findings
+	}
+	anonymous := make([]models.Subject, 0)
+	for _, s := range binding.Subjects {
+		if s.Name == "system:anonymous" || s.Name == "system:unauthenticated" {
+			anonymous = append(anonymous, s)
+		}
+	}
+	if len(anonymous) == 0 {
+		return findings
+	}
+	return append(findings, Finding{
+		ID:          "KR-RBAC-005",
+		Severity:    SeverityCritical,
+		Category:    "RBAC",
+		Kind:        kind,
+		Namespace:   binding.Namespace,
+		Name:        binding.Name,
+		Title:       "Role granted to unauthenticated users",
+		Detail:      fmt.Sprintf("%s bound to %s", formatSubjects(anonymous), binding.RoleRef),
+		Remediation: "Remove anonymous and unauthenticated subjects from the binding.",
+	})
+}
+
+// checkAggregatedRoles flags default ClusterRoles such as admin and edit that
+// aggregate rules from ClusterRoles Kubernetes did not create. Every subject
+// bound to the default role receives those rules without any binding change.
+func checkAggregatedRoles(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	sources := rbac.AggregationSources(data.RBAC.ClusterRoles)
+	for _, role := range data.RBAC.ClusterRoles {
+		if !rbac.BuiltIn(role.Name) {
+			continue
+		}
+		thirdParty := rbac.ThirdPartySources(sources[role.Name])
+		if len(thirdParty) == 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:          "KR-RBAC-009",
+			Severity:    SeverityMedium,
+			Category:    "RBAC",
+			Kind:        "ClusterRole",
+			Name:        role.Name,
+			Title:       "Default ClusterRole extended through aggregation",
+			Detail:      fmt.Sprintf("Rules of %s are aggregated into %s through the selector %s", strings.Join(thirdParty, ", "), role.Name, strings.Join(role.AggregationRule, " or ")),
+			Remediation: "Review the rules the listed ClusterRoles add, and remove the aggregation label from those that should not extend the default role.",
+		})
+	}
+	return findings
+}
+
+// isClusterAdmin reports whether a binding grants the cluster-admin ClusterRole
+func isClusterAdmin(ref models.RoleRef) bool {
+	return ref.Kind == "ClusterRole" && ref.Name == "cluster-admin"
+}
+
+// checkDanglingBindings flags bindings whose role, ServiceAccount subjects or
+// their namespaces do not exist. Whoever creates the missing object first
+// receives the binding's permissions. Kinds that were not fully collected are
+// not checked, as a missing object may only be a collection gap.
+func checkDanglingBindings(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	complete := make(map[string]bool)
+	for _, k := range data.Coverage.Kinds {
+		complete[k.Kind] = k.Status == models.CoverageComplete
+	}
+
+	exists := make(map[string]bool)
+	for _, role := range data.RBAC.ClusterRoles {
+		exists["ClusterRole/"+role.Name] = true
+	}
+	for _, role := range data.RBAC.Roles {
+		exists["Role/"+role.Namespace+"/"+role.Name] = true
+	}
+	for _, sa := range data.RBAC.ServiceAccounts {
+		exists["ServiceAccount/"+sa.Namespace+"/"+sa.Name] = true
+	}
+	for _, ns := range data.ClusterInfo.Namespaces {
+		exists["Namespace/"+ns.Name] = true
+	}
+
+	check := func(kind string, binding models.BindingInfo) {
+		newFinding := func(id string, sev Severity, title, detail, remediation string) Finding {
+			return Finding{
+				ID:          id,
+				Severity:    sev,
+				Category:    "RBAC",
+				Kind:        kind,
+				Namespace:   binding.Namespace,
+				Name:        binding.Name,
+				Title:       title,
+				Detail:      detail,
+				Remediation: remediation,
+			}
+		}
+
+		roleKey, roleKind := "ClusterRole/"+binding.RoleRef.Name, "ClusterRoles"
+		if binding.RoleRef.Kind == "Role" {
+			roleKey, roleKind = "Role/"+binding.Namespace+"/"+binding.RoleRef.Name, "Roles"
+		}
+		if complete[roleKind] && !exists[roleKey] {
+			findings = append(findings, newFinding("KR-RBAC-007", SeverityMedium,
+				"Binding references a missing role",
+				fmt.Sprintf("%s does not exist; whoever creates it decides the permissions of %s", binding.RoleRef, formatSubjects(binding.Subjects)),
+				"Delete the binding, or recreate the role it was meant to grant."))
+		}
+
+		missing := make([]string, 0)
+		for _, s := range binding.Subjects {
+			if s.Kind != "ServiceAccount" {
+				continue
+			}
+			switch {
+			case complete["Namespaces"] && !exists["Namespace/"+s.Namespace]:
+				missing = append(missing, fmt.Sprintf("%s (namespace %s does not exist)", formatSubject(s), s.Namespace))
+			case complete["ServiceAccounts"] && !exists["ServiceAccount/"+s.Namespace+"/"+s.Name]:
+				missing = append(missing, formatSubject(s))
+			}
+		}
+		if len(missing) > 0 {
+			findings = append(findings, newFinding("KR-RBAC-008", SeverityHigh,
+				"Binding grants a missing ServiceAccount",
+				fmt.Sprintf("%s is granted to nonexistent %s; anyone able to create them inherits it", binding.RoleRef, strings.Join(missing, ", ")),
+				"Remove the missing subjects from the binding."))
+		}
+	}
+
+	for _, crb := range data.RBAC.ClusterRoleBindings {
+		check("ClusterRoleBinding", crb)
+	}
+	for _, rb := range data.RBAC.RoleBindings {
+		check("RoleBinding", rb)
+	}
+	return findings
+}
+
+func formatSubjects(subjects []models.Subject) string {
+	names := make([]string, 0, len(subjects))
+	for _, s := range subjects {
+		names = append(names, formatSubject(s))
+	}
+	return strings.Join(names, ", ")
+}
+
+func formatSubject(s models.Subject) string {
+	if s.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", s.Kind, s.Namespace, s.Name)
+	}
+	return fmt.Sprintf("%s %s", s.Kind, s.Name)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"testing"
+
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rbac"
+)
+
+func TestCheckDanglingBindings(t *testing.T) {
+	data := &models.AssessmentData{}
+	data.ClusterInfo.Namespaces = []models.NamespaceInfo{{Name: "payments"}}
+	data.RBAC.ServiceAccounts = []models.ServiceAccountInfo{{Name: "api", Namespace: "payments"}}
+	data.RBAC.ClusterRoles = []models.RoleInfo{{Name: "view", ClusterRole: true}}
+	data.RBAC.RoleBindings = []models.BindingInfo{
+		{Name: "ok", Namespace: "payments", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects: []models.Subject{{Kind: "ServiceAccount", Namespace: "payments", Name: "api"}}},
+		// A ClusterRole of the same name does not satisfy a Role reference
+		{Name: "missing-role", Namespace: "payments", RoleRef: models.RoleRef{Kind: "Role", Name: "view"},
+			Subjects: []models.Subject{{Kind: "User", Name: "alice"}}},
+		{Name: "missing-subjects", Namespace: "payments", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "view"},
+			Subjects: []models.Subject{
+				{Kind: "ServiceAccount", Namespace: "payments", Name: "deployer"},
+				{Kind: "ServiceAccount", Namespace: "old-team", Name: "builder"},
+			}},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		coverage string
+		want     []string
+	}{
+		{"complete", models.CoverageComplete, []string{"missing-role KR-RBAC-007", "missing-subjects KR-RBAC-008"}},
+		{"partial", models.CoveragePartial, []string{}},
+	} {
+		data.Coverage.Kinds = nil
+		for _, kind := range []string{"Namespaces", "ServiceAccounts", "Roles", "ClusterRoles"} {
+			data.Coverage.Kinds = append(data.Coverage.Kinds, models.KindCoverage{Kind: kind, Status: tt.coverage})
+		}
+		got := make([]string, 0)
+		for _, f := range checkDanglingBindings(data) {
+			got = append(got, f.Name+" "+f.ID)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: findings = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: findings = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestAnonymousBindingFindings(t *testing.T) {
+	anonymous := []models.Subject{{Kind: "Group", Name: "system:unauthenticated"}}
+	for _, tt := range []struct {
+		binding models.BindingInfo
+		want    int
+	}{
+		{models.BindingInfo{Name: "system:public-info-viewer", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "system:public-info-viewer"}, Subjects: anonymous}, 0},
+		{models.BindingInfo{Name: "system:discovery", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "system:discovery"}, Subjects: anonymous}, 0},
+		// No longer bound to unauthenticated users since Kubernetes 1.14
+		{models.BindingInfo{Name: "system:basic-user", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "system:basic-user"}, Subjects: anonymous}, 1},
+		// A namespaced Role of the same name is not reconciled by the API server
+		{models.BindingInfo{Name: "lookalike", Namespace: "payments", RoleRef: models.RoleRef{Kind: "Role", Name: "system:public-info-viewer"}, Subjects: anonymous}, 1},
+		{models.BindingInfo{Name: "anonymous-view", RoleRef: models.RoleRef{Kind: "ClusterRole", Name: "view"}, Subjects: anonymous}, 1},
+	} {
+		if got := anonymousBindingFindings("ClusterRoleBinding", tt.binding); len(got) != tt.want {
+			t.Errorf("anonymousBindingFindings(%s) returned %d findings, want %d", tt.binding.Name, len(got), tt.want)
+		}
+	}
+}
+
+func TestIsPublicGrant(t *testing.T) {
+	public := models.PolicyRule{NonResourceURLs: []string{"/healthz", "/version"}, Verbs: []string{"get"}}
+	tests := []struct {
+		grant rbac.Grant
+		want  bool
+	}{
+		{rbac.Grant{RoleKind: "ClusterRole", RoleName: "system:public-info-viewer", Rule: public}, true},
+		{rbac.Grant{RoleKind: "ClusterRole", RoleName: "system:discovery", Rule: public}, true},
+		{rbac.Grant{RoleKind: "ClusterRole", RoleName: "system:basic-user", Rule: models.PolicyRule{
+			APIGroups: []string{"authorization.k8s.io"}, Resources: []string{"selfsubjectaccessreviews"}, Verbs: []string{"create"},
+		}}, false},
+		{rbac.Grant{RoleKind: "ClusterRole", RoleName: "metrics-reader", Rule: public}, false},
+	}
+	for _, tt := range tests {
+		if got := IsPublicGrant(tt.grant); got != tt.want {
+			t.Errorf("IsPublicGrant(%s) = %v, want %v", tt.grant.RoleName, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"sort"
+
+	"kubeRadar/pkg/models"
+)
+
+// Severity ranks how urgently a finding needs attention
+type Severity string
+
+const (
+	SeverityCritical Severity = "Critical"
+	SeverityHigh     Severity = "High"
+	SeverityMedium   Severity = "Medium"
+	SeverityLow      Severity = "Low"
+)
+
+// Severities lists all severities from most to least urgent
+var Severities = []Severity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow}
+
+// Rank returns the sort position of a severity, lower is more urgent
+func (s Severity) Rank() int {
+	for i, sev := range Severities {
+		if sev == s {
+			return i
+		}
+	}
+	return len(Severities)
+}
+
+// Finding represents a single security issue found in the assessment data
+// | ID | Severity | Category | Kind | Namespace | Name | Title | Detail | Remediation |
+type Finding struct {
+	ID          string
+	Severity    Severity
+	Category    string
+	Kind        string
+	Namespace   string
+	Name        string
+	Title       string
+	Detail      string
+	Remediation string
+}
+
+// rule inspects the assessment data and returns the findings it produces
+type rule func(data *models.AssessmentData) []Finding
+
+// allRules is the ordered set of rules evaluated by Evaluate
+var allRules = []rule{
+	checkPodSecurity,
+	checkRBACRules,
+	checkRBACBindings,
+	checkDanglingBindings,
+	checkAggregatedRoles,
+	checkEscalationPaths,
+	checkExposedTokens,
+	checkNetworkPolicies,
+	checkServiceExposure,
+	checkIngressTLS,
+	checkConfigMapCredentials,
+	checkCertificates,
+}
+
+// Evaluate runs every rule against the assessment data and returns the
+// findings ordered by severity, then by rule ID and affected object
+func Evaluate(data *models.AssessmentData) []Finding {
+	findings := make([]Finding, 0)
+	if data == nil {
+		return findings
+	}
+	for _, r := range allRules {
+		findings = append(findings, r(data)...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity.Rank() != b.Severity.Rank() {
+			return a.Severity.Rank() < b.Severity.Rank()
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return findings
+}
+
+// CountBySeverity returns the number of findings for each severity
+func CountBySeverity(findings []Finding) map[Severity]int {
+	counts := make(map[Severity]int)
+	for _, f := range findings {
+		counts[f.Severity]++
+	}
+	return counts
+}
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"kubeRadar/pkg/models"
+)
+
+// Sysctl classes
+const (
+	// SysctlSafe sysctls are namespaced and isolated between pods, so the
+	// kubelet allows them by default
+	SysctlSafe = "Safe"
+	// SysctlUnsafe sysctls are namespaced but can affect other pods or the
+	// node, and have to be allowed with --allowed-unsafe-sysctls
+	SysctlUnsafe = "Unsafe"
+	// SysctlNode sysctls are not namespaced and apply to the whole node.
+	// The kubelet refuses pods setting them.
+	SysctlNode = "Node-level"
+)
+
+// safeSysctls is the safe sysctl set of the kubelet
+var safeSysctls = map[string]bool{
+	"kernel.shm_rmid_forced":              true,
+	"net.ipv4.ip_local_port_range":        true,
+	"net.ipv4.ip_local_reserved_ports":    true,
+	"net.ipv4.tcp_syncookies":             true,
+	"net.ipv4.ping_group_range":           true,
+	"net.ipv4.ip_unprivileged_port_start": true,
+	"net.ipv4.tcp_keepalive_time":         true,
+	"net.ipv4.tcp_fin_timeout":            true,
+	"net.ipv4.tcp_keepalive_intvl":        true,
+	"net.ipv4.tcp_keepalive_probes":       true,
+	"net.ipv4.tcp_rmem":                   true,
+	"net.ipv4.tcp_wmem":                   true,
+}
+
+// namespacedSysctlPrefixes are the IPC and network sysctls that the kernel
+// isolates per namespace
+var namespacedSysctlPrefixes = []string{"kernel.shm", "kernel.msg", "kernel.sem", "fs.mqueue.", "net."}
+
+// ClassifySysctl returns the class of a sysctl. Names may use / as the
+// separator, as allowed in the pod spec.
+func ClassifySysctl(name string) string {
+	name = strings.ReplaceAll(name, "/", ".")
+	if safeSysctls[name] {
+		return SysctlSafe
+	}
+	for _, prefix := range namespacedSysctlPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return SysctlUnsafe
+		}
+	}
+	return SysctlNode
+}
+
+func sysctlFindings(newFinding func(id string, sev Severity, title, detail, remediation string) Finding, sysctls []models.Sysctl) []Finding {
+	findings := make([]Finding, 0)
+	unsafe := make([]string, 0)
+	for _, sysctl := range sysctls {
+		if class := ClassifySysctl(sysctl.Name); class != SysctlSafe {
+			unsafe = append(unsafe, fmt.Sprintf("%s=%s (%s)", sysctl.Name, sysctl.Value, class))
+		}
+	}
+	if len(unsafe) > 0 {
+		findings = append(findings, newFinding("KR-POD-015", SeverityMedium,
+			"Unsafe sysctls",
+			fmt.Sprintf("Sysctls outside the safe set: %s", strings.Join(unsafe, ", ")),
+			"Remove the sysctls, or tune the parameters on dedicated nodes and keep --allowed-unsafe-sysctls as narrow as possible."))
+	}
+	return findings
+}
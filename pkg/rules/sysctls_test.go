@@ -0,0 +1,24 @@
+package rules
+
+import "testing"
+
+func TestClassifySysctl(t *testing.T) {
+	tests := map[string]string{
+		"kernel.shm_rmid_forced":       SysctlSafe,
+		"net.ipv4.ip_local_port_range": SysctlSafe,
+		"net/ipv4/tcp_syncookies":      SysctlSafe,
+		"net.ipv4.ip_forward":          SysctlUnsafe,
+		"net.core.somaxconn":           SysctlUnsafe,
+		"kernel.msgmax":                SysctlUnsafe,
+		"kernel/shmmax":                SysctlUnsafe,
+		"fs.mqueue.msg_max":            SysctlUnsafe,
+		"kernel.panic":                 SysctlNode,
+		"vm.overcommit_memory":         SysctlNode,
+		"fs.file-max":                  SysctlNode,
+	}
+	for name, want := range tests {
+		if got := ClassifySysctl(name); got != want {
+			t.Errorf("ClassifySysctl(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
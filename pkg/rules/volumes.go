@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"kubeRadar/pkg/models"
+)
+
+// sensitiveHostPaths are node paths that give control over the node or its
+// workloads when mounted into a container: container runtime sockets,
+// kubelet state and credentials, and the control plane configuration
+var sensitiveHostPaths = []string{
+	"/var/run/docker.sock",
+	"/run/docker.sock",
+	"/var/run/containerd",
+	"/run/containerd",
+	"/var/run/crio",
+	"/run/crio",
+	"/var/lib/docker",
+	"/var/lib/containerd",
+	"/var/lib/kubelet",
+	"/etc/kubernetes",
+	"/root",
+	"/proc",
+	"/dev",
+}
+
+// SensitiveHostPath reports whether mounting the host path exposes a
+// sensitive node path, either because it lies below one or because it is a
+// parent directory of one, like / or /var
+func SensitiveHostPath(hostPath string) bool {
+	p := path.Clean("/" + hostPath)
+	if p == "/" {
+		return true
+	}
+	for _, sensitive := range sensitiveHostPaths {
+		if p == sensitive || strings.HasPrefix(p, sensitive+"/") || strings.HasPrefix(sensitive, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// WritableHostPath reports whether a hostPath volume is mounted writable into
+// at least one container
+func WritableHostPath(v models.VolumeInfo) bool {
+	if v.Type != "hostPath" {
+		return false
+	}
+	for _, m := range v.Mounts {
+		if !m.ReadOnly {
+			return true
+		}
+	}
+	return false
+}
+
+func hostPathFindings(newFinding func(id string, sev Severity, title, detail, remediation string) Finding, volumes []models.VolumeInfo) []Finding {
+	findings := make([]Finding, 0)
+	sensitive := make([]string, 0)
+	writable := make([]string, 0)
+	for _, v := range volumes {
+		if v.Type != "hostPath" {
+			continue
+		}
+		if SensitiveHostPath(v.Source) {
+			sensitive = append(sensitive, fmt.Sprintf("%s: %s", v.Name, v.Source))
+		}
+		if WritableHostPath(v) {
+			writable = append(writable, fmt.Sprintf("%s: %s", v.Name, v.Source))
+		}
+	}
+
+	if len(sensitive) > 0 {
+		findings = append(findings, newFinding("KR-POD-010", SeverityCritical,
+			"Sensitive host path mounted",
+			fmt.Sprintf("hostPath volumes exposing node internals: %s", strings.Join(sensitive, ", ")),
+			"Remove the hostPath volume; node agents that need the runtime socket or kubelet state should run in a dedicated, tightly controlled namespace."))
+	}
+	if len(writable) > 0 {
+		findings = append(findings, newFinding("KR-POD-011", SeverityHigh,
+			"Writable hostPath volume",
+			fmt.Sprintf("hostPath volumes mounted read-write: %s", strings.Join(writable, ", ")),
+			"Mount hostPath volumes with readOnly: true, or replace them with emptyDir or persistent volumes."))
+	}
+	return findings
+}
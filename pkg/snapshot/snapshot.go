@@ -0,0 +1,203 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"kubeRadar/pkg/models"
+
+	"sigs.k8s.io/yaml"
+)
+
+// SchemaVersion is the snapshot schema written by this build. Bump it when a
+// change to the models package would break decoding of older snapshots and
+// register a migration from the previous version.
+const SchemaVersion = 3
+
+// Supported snapshot formats
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Snapshot is the on-disk representation of a collected assessment
+// | SchemaVersion | GeneratedAt | Data |
+type Snapshot struct {
+	SchemaVersion int
+	GeneratedAt   string
+	Data          *models.AssessmentData
+}
+
+// migration upgrades a decoded snapshot document by exactly one version
+type migration func(doc map[string]interface{}) error
+
+// migrations maps a schema version to the function upgrading it to the next one
+var migrations = map[int]migration{
+	1: migrateRoleRefs,
+	2: migrateSecretsInspected,
+}
+
+// migrateRoleRefs converts the RoleRef of bindings from the role name to an
+// object with APIGroup, Kind and Name. Version 1 did not record the kind:
+// ClusterRoleBindings always reference a ClusterRole, and a RoleBinding is
+// taken to reference the Role of its namespace when one has that name.
+func migrateRoleRefs(doc map[string]interface{}) error {
+	data, _ := doc["Data"].(map[string]interface{})
+	rbac, _ := data["RBAC"].(map[string]interface{})
+	if rbac == nil {
+		return nil
+	}
+
+	roles := make(map[string]bool)
+	items, _ := rbac["Roles"].([]interface{})
+	for _, item := range items {
+		role, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected Role entry %v", item)
+		}
+		roles[fmt.Sprintf("%v/%v", role["Namespace"], role["Name"])] = true
+	}
+
+	convert := func(key string, kindOf func(binding map[string]interface{}, name string) string) error {
+		items, _ := rbac[key].([]interface{})
+		for _, item := range items {
+			binding, ok := item.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("unexpected %s entry %v", key, item)
+			}
+			name, ok := binding["RoleRef"].(string)
+			if !ok {
+				return fmt.Errorf("%s %v has no RoleRef name", key, binding["Name"])
+			}
+			binding["RoleRef"] = map[string]interface{}{
+				"APIGroup": "rbac.authorization.k8s.io",
+				"Kind":     kindOf(binding, name),
+				"Name":     name,
+			}
+		}
+		return nil
+	}
+	if err := convert("ClusterRoleBindings", func(map[string]interface{}, string) string {
+		return "ClusterRole"
+	}); err != nil {
+		return err
+	}
+	return convert("RoleBindings", func(binding map[string]interface{}, name string) string {
+		if roles[fmt.Sprintf("%v/%s", binding["Namespace"], name)] {
+			return "Role"
+		}
+		return "ClusterRole"
+	})
+}
+
+// migrateSecretsInspected records whether Secret payloads were read, which
+// version 2 did not. Keys are only collected from payloads, so inspection ran
+// when any Secret has a Keys list, even an empty one.
+func migrateSecretsInspected(doc map[string]interface{}) error {
+	data, _ := doc["Data"].(map[string]interface{})
+	secrets, _ := data["Secrets"].(map[string]interface{})
+	if secrets == nil {
+		return nil
+	}
+	items, _ := secrets["Secrets"].([]interface{})
+	inspected := false
+	for _, item := range items {
+		secret, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected Secret entry %v", item)
+		}
+		if keys, ok := secret["Keys"]; ok && keys != nil {
+			inspected = true
+		}
+	}
+	secrets["Inspected"] = inspected
+	return nil
+}
+
+// Save writes the assessment data to path in the given format
+func Save(path string, format string, data *models.AssessmentData) error {
+	snap := Snapshot{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		Data:          data,
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	switch format {
+	case FormatJSON:
+		out, err = json.MarshalIndent(snap, "", "  ")
+	case FormatYAML:
+		out, err = yaml.Marshal(snap)
+	default:
+		return fmt.Errorf("unsupported snapshot format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+
+	if err := os.WriteFile(path, out, 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshot: %v", err)
+	}
+	return nil
+}
+
+// Load reads a JSON or YAML snapshot from path, upgrading older schema
+// versions to the current one
+func Load(path string) (*models.AssessmentData, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %v", err)
+	}
+	return Decode(raw)
+}
+
+// Decode parses a JSON or YAML snapshot document
+func Decode(raw []byte) (*models.AssessmentData, error) {
+	// JSON is valid YAML, so both formats go through the same conversion
+	jsonData, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %v", err)
+	}
+
+	doc := make(map[string]interface{})
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %v", err)
+	}
+
+	version, ok := doc["SchemaVersion"].(float64)
+	if !ok || version < 1 {
+		return nil, fmt.Errorf("snapshot has no valid SchemaVersion")
+	}
+	if int(version) > SchemaVersion {
+		return nil, fmt.Errorf("snapshot schema version %d is newer than supported version %d", int(version), SchemaVersion)
+	}
+
+	for v := int(version); v < SchemaVersion; v++ {
+		migrate, ok := migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("no migration from snapshot schema version %d", v)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate snapshot from version %d: %v", v, err)
+		}
+		doc["SchemaVersion"] = v + 1
+	}
+
+	jsonData, err = json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode snapshot: %v", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(jsonData, &snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %v", err)
+	}
+	if snap.Data == nil {
+		return nil, fmt.Errorf("snapshot contains no assessment data")
+	}
+	return snap.Data, nil
+}
@@ -0,0 +1,108 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"kubeRadar/pkg/collector"
+	"kubeRadar/pkg/fixture"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	c := collector.NewCollectorForClients(collector.Clients{Kube: fixture.Clientset()}, &rest.Config{Host: "https://fixture.example.com:6443"}, collector.DefaultOptions())
+	data, err := c.CollectAll()
+	if err != nil {
+		t.Fatalf("CollectAll failed: %v", err)
+	}
+	want, _ := json.Marshal(data)
+
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "snapshot."+format)
+			if err := Save(path, format, data); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			loaded, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if got, _ := json.Marshal(loaded); string(got) != string(want) {
+				t.Errorf("round trip changed the data:\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsInvalidSchema(t *testing.T) {
+	tests := map[string]string{
+		"missing version": `{"Data": {}}`,
+		"newer version":   `{"SchemaVersion": 999, "Data": {}}`,
+		"no data":         `{"SchemaVersion": 1}`,
+	}
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Decode([]byte(doc)); err == nil {
+				t.Errorf("Decode accepted %s", strings.TrimSpace(doc))
+			}
+		})
+	}
+}
+
+func TestDecodeMigratesRoleRefs(t *testing.T) {
+	doc := `{
+  "SchemaVersion": 1,
+  "Data": {
+    "RBAC": {
+      "ClusterRoleBindings": [{"Name": "ops-admin", "RoleRef": "cluster-admin"}],
+      "Roles": [{"Name": "config-editor", "Namespace": "payments"}],
+      "RoleBindings": [
+        {"Name": "editors", "Namespace": "payments", "RoleRef": "config-editor"},
+        {"Name": "viewers", "Namespace": "payments", "RoleRef": "view"},
+        {"Name": "editors", "Namespace": "default", "RoleRef": "config-editor"}
+      ]
+    }
+  }
+}`
+	data, err := Decode([]byte(doc))
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	want := []string{"ClusterRole/cluster-admin", "Role/config-editor", "ClusterRole/view", "ClusterRole/config-editor"}
+	got := make([]string, 0)
+	for _, b := range append(data.RBAC.ClusterRoleBindings, data.RBAC.RoleBindings...) {
+		if b.RoleRef.APIGroup != "rbac.authorization.k8s.io" {
+			t.Errorf("%s/%s: APIGroup = %q", b.Namespace, b.Name, b.RoleRef.APIGroup)
+		}
+		got = append(got, b.RoleRef.String())
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("migrated RoleRefs = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeMigratesSecretsInspected(t *testing.T) {
+	tests := []struct {
+		secrets string
+		want    bool
+	}{
+		{`[{"Name": "api-tls", "Type": "kubernetes.io/tls", "Keys": ["tls.crt", "tls.key"]}]`, true},
+		{`[{"Name": "empty", "Type": "Opaque", "Keys": []}]`, true},
+		{`[{"Name": "api-tls", "Type": "", "Keys": null}]`, false},
+		{`[]`, false},
+	}
+	for _, tt := range tests {
+		doc := `{"SchemaVersion": 2, "Data": {"Secrets": {"Secrets": ` + tt.secrets + `}}}`
+		data, err := Decode([]byte(doc))
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if data.Secrets.Inspected != tt.want {
+			t.Errorf("%s: Inspected = %v, want %v", tt.secrets, data.Secrets.Inspected, tt.want)
+		}
+	}
+}
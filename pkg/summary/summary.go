@@ -0,0 +1,234 @@
+// Package summary computes the dashboard figures shared by the report
+// renderers, so every output format shows the same numbers.
+package summary
+
+import (
+	"fmt"
+	"sort"
+
+	"kubeRadar/pkg/models"
+	"kubeRadar/pkg/rules"
+)
+
+// Metric is a labelled figure shown on the dashboard
+// | Label | Value |
+type Metric struct {
+	Label string
+	Value interface{}
+}
+
+// Dashboard holds the figures of the report dashboard
+// | Overview | RBAC | PodSecurity | Findings | Certificates | ExpiringCertificates |
+type Dashboard struct {
+	Overview    []Metric
+	RBAC        []Metric
+	PodSecurity []Metric
+	Findings    []Metric // one per severity, in rules.Severities order
+	// Certificates counts expired certificates and those expiring within
+	// each of rules.CertificateExpiryWindows. Every value is NotAnalyzed
+	// when Secrets were not inspected.
+	Certificates         []Metric
+	ExpiringCertificates []ExpiringCertificate
+}
+
+// NotAnalyzed is the value of metrics that could not be computed from the
+// collected data
+const NotAnalyzed = "not analyzed"
+
+// ExpiringCertificate is a certificate that has expired or expires within
+// the largest expiry window
+// | Namespace | Secret | Subject | NotAfter | DaysLeft |
+type ExpiringCertificate struct {
+	Namespace string
+	Secret    string
+	Subject   string
+	NotAfter  string
+	DaysLeft  int
+}
+
+// NewDashboard computes the dashboard figures for the data and its findings
+func NewDashboard(data *models.AssessmentData, findings []rules.Finding) Dashboard {
+	expiring := expiringCertificates(data)
+	return Dashboard{
+		Overview:             overview(data),
+		RBAC:                 rbac(data),
+		PodSecurity:          podSecurity(data),
+		Findings:             findingCounts(findings),
+		Certificates:         certificateCounts(data, expiring),
+		ExpiringCertificates: expiring,
+	}
+}
+
+func overview(data *models.AssessmentData) []Metric {
+	return []Metric{
+		{"Kubernetes Version", data.ClusterInfo.Version},
+		{"Total Nodes", data.ClusterInfo.NodeCount},
+		{"Total Namespaces", len(data.ClusterInfo.Namespaces)},
+		{"Total Pods", len(data.Workloads.Pods)},
+		{"Total Deployments", len(data.Workloads.Deployments)},
+		{"Total StatefulSets", len(data.Workloads.StatefulSets)},
+		{"Total DaemonSets", len(data.Workloads.DaemonSets)},
+		{"Total Jobs", len(data.Workloads.Jobs)},
+		{"Total CronJobs", len(data.Workloads.CronJobs)},
+		{"Total ReplicaSets", len(data.Workloads.ReplicaSets)},
+		{"Total ReplicationControllers", len(data.Workloads.ReplicationControllers)},
+		{"Total Services", len(data.Network.Services)},
+		{"Total Network Policies", len(data.Network.NetworkPolicies)},
+		{"Total Ingresses", len(data.Network.Ingresses)},
+		{"Total Secrets", len(data.Secrets.Secrets)},
+		{"Total ConfigMaps", len(data.ConfigMaps.ConfigMaps)},
+		{"Total Roles", len(data.RBAC.Roles)},
+		{"Total ClusterRoles", len(data.RBAC.ClusterRoles)},
+		{"Total RoleBindings", len(data.RBAC.RoleBindings)},
+		{"Total ClusterRoleBindings", len(data.RBAC.ClusterRoleBindings)},
+		{"Total ServiceAccounts", len(data.RBAC.ServiceAccounts)},
+	}
+}
+
+func rbac(data *models.AssessmentData) []Metric {
+	return []Metric{
+		{"Roles", len(data.RBAC.Roles)},
+		{"ClusterRoles", len(data.RBAC.ClusterRoles)},
+		{"RoleBindings", len(data.RBAC.RoleBindings)},
+		{"ClusterRoleBindings", len(data.RBAC.ClusterRoleBindings)},
+		{"ServiceAccounts", len(data.RBAC.ServiceAccounts)},
+	}
+}
+
+func podSecurity(data *models.AssessmentData) []Metric {
+	podPrivileged := 0
+	podHostNetwork := 0
+	podHostPID := 0
+	podHostIPC := 0
+	podRunAsRoot := 0
+	podEphemeral := 0
+	podSeccompUnconfined := 0
+	podAppArmorUnconfined := 0
+	podUnmaskedProc := 0
+	podShareProcess := 0
+	for _, pod := range data.Workloads.Pods {
+		seccomp, appArmor, procMount := false, false, false
+		for _, c := range pod.Containers {
+			seccomp = seccomp || rules.EffectiveSeccompProfile(pod.SecurityContext, c.SecurityContext) == "Unconfined"
+			appArmor = appArmor || rules.EffectiveAppArmorProfile(pod.SecurityContext, c.SecurityContext) == "Unconfined"
+			procMount = procMount || c.SecurityContext.ProcMount == "Unmasked"
+		}
+		if seccomp {
+			podSeccompUnconfined++
+		}
+		if appArmor {
+			podAppArmorUnconfined++
+		}
+		if procMount {
+			podUnmaskedProc++
+		}
+		if pod.SecurityContext.ShareProcessNamespace {
+			podShareProcess++
+		}
+		for _, c := range pod.Containers {
+			if c.Type == models.ContainerTypeEphemeral {
+				podEphemeral++
+				break
+			}
+		}
+		for _, c := range pod.Containers {
+			if c.SecurityContext.Privileged {
+				podPrivileged++
+				break
+			}
+			if c.SecurityContext.RunAsUser != nil && *c.SecurityContext.RunAsUser == 0 {
+				podRunAsRoot++
+				break
+			}
+		}
+		if pod.SecurityContext.HostNetwork {
+			podHostNetwork++
+		}
+		if pod.SecurityContext.HostPID {
+			podHostPID++
+		}
+		if pod.SecurityContext.HostIPC {
+			podHostIPC++
+		}
+	}
+	return []Metric{
+		{"Total Pods", len(data.Workloads.Pods)},
+		{"Privileged", podPrivileged},
+		{"Host Network", podHostNetwork},
+		{"Host PID", podHostPID},
+		{"Host IPC", podHostIPC},
+		{"RunAsRoot", podRunAsRoot},
+		{"Ephemeral Containers", podEphemeral},
+		{"Seccomp Unconfined", podSeccompUnconfined},
+		{"AppArmor Unconfined", podAppArmorUnconfined},
+		{"Unmasked ProcMount", podUnmaskedProc},
+		{"Share Process Namespace", podShareProcess},
+	}
+}
+
+// expiringCertificates lists the certificates that expired or expire within
+// the largest window, soonest first
+func expiringCertificates(data *models.AssessmentData) []ExpiringCertificate {
+	now := rules.ReferenceTime(data)
+	window := rules.CertificateExpiryWindows[len(rules.CertificateExpiryWindows)-1]
+	expiring := make([]ExpiringCertificate, 0)
+	for _, secret := range data.Secrets.Secrets {
+		for _, cert := range secret.Certificates {
+			days, ok := rules.DaysUntilExpiry(cert, now)
+			if !ok || days > window {
+				continue
+			}
+			expiring = append(expiring, ExpiringCertificate{
+				Namespace: secret.Namespace,
+				Secret:    secret.Name,
+				Subject:   cert.Subject,
+				NotAfter:  cert.NotAfter,
+				DaysLeft:  days,
+			})
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].DaysLeft < expiring[j].DaysLeft
+	})
+	return expiring
+}
+
+// certificateCounts counts the expired certificates and, cumulatively, those
+// expiring within each window. Certificates are only parsed from inspected
+// Secrets, so without inspection no count is known.
+func certificateCounts(data *models.AssessmentData, expiring []ExpiringCertificate) []Metric {
+	if !data.Secrets.Inspected {
+		metrics := []Metric{{"Expired", NotAnalyzed}}
+		for _, window := range rules.CertificateExpiryWindows {
+			metrics = append(metrics, Metric{fmt.Sprintf("Expiring within %d days", window), NotAnalyzed})
+		}
+		return metrics
+	}
+	expired := 0
+	within := make([]int, len(rules.CertificateExpiryWindows))
+	for _, cert := range expiring {
+		if cert.DaysLeft < 0 {
+			expired++
+			continue
+		}
+		for i, window := range rules.CertificateExpiryWindows {
+			if cert.DaysLeft <= window {
+				within[i]++
+			}
+		}
+	}
+	metrics := []Metric{{"Expired", expired}}
+	for i, window := range rules.CertificateExpiryWindows {
+		metrics = append(metrics, Metric{fmt.Sprintf("Expiring within %d days", window), within[i]})
+	}
+	return metrics
+}
+
+func findingCounts(findings []rules.Finding) []Metric {
+	counts := rules.CountBySeverity(findings)
+	metrics := make([]Metric, 0, len(rules.Severities))
+	for _, sev := range rules.Severities {
+		metrics = append(metrics, Metric{string(sev), counts[sev]})
+	}
+	return metrics
+}
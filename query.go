@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"kubeRadar/pkg/excel"
+	"kubeRadar/pkg/rbac"
+	"kubeRadar/pkg/snapshot"
+)
+
+// runWhoCan implements `kubeRadar who-can --input <snapshot> <verb> <resource>`
+func runWhoCan(args []string) error {
+	fs := flag.NewFlagSet("who-can", flag.ExitOnError)
+	input := fs.String("input", "", "Snapshot to query")
+	namespace := fs.String("namespace", "", "Only report permissions that apply in this namespace")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: kubeRadar who-can --input cluster.json [--namespace ns] <verb> <resource>")
+		fmt.Fprintln(fs.Output(), "Resources may name a subresource and an API group, e.g. pods/exec or deployments.apps, or be a non-resource URL such as /metrics")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *input == "" || fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("who-can expects --input and a verb and a resource")
+	}
+
+	resolver, err := loadResolver(*input)
+	if err != nil {
+		return err
+	}
+	grants := resolver.WhoCan(fs.Arg(0), fs.Arg(1), *namespace)
+	if len(grants) == 0 {
+		fmt.Fprintf(os.Stderr, "[kubeRadar] No subject can %s %s\n", fs.Arg(0), fs.Arg(1))
+		return nil
+	}
+	return printGrants(grants)
+}
+
+// runWhatCan implements `kubeRadar what-can --input <snapshot> <subject>`
+func runWhatCan(args []string) error {
+	fs := flag.NewFlagSet("what-can", flag.ExitOnError)
+	input := fs.String("input", "", "Snapshot to query")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: kubeRadar what-can --input cluster.json <subject>")
+		fmt.Fprintln(fs.Output(), "Subjects are written User:name, Group:name, ServiceAccount:namespace/name or system:serviceaccount:namespace:name")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *input == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("what-can expects --input and a subject")
+	}
+
+	subject, err := rbac.ParseSubject(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	resolver, err := loadResolver(*input)
+	if err != nil {
+		return err
+	}
+	grants := resolver.WhatCan(subject)
+	if len(grants) == 0 {
+		fmt.Fprintf(os.Stderr, "[kubeRadar] No binding grants %s any permission\n", rbac.SubjectName(subject))
+		return nil
+	}
+	return printGrants(grants)
+}
+
+func loadResolver(path string) (*rbac.Resolver, error) {
+	fmt.Fprintf(os.Stderr, "[kubeRadar] Loading snapshot %s...\n", path)
+	data, err := snapshot.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading %s: %v", path, err)
+	}
+	for _, k := range data.Coverage.Incomplete() {
+		switch k.Kind {
+		case "Roles", "RoleBindings", "ClusterRoles", "ClusterRoleBindings":
+			fmt.Fprintf(os.Stderr, "[kubeRadar] Warning: %s were not fully collected in this snapshot, some permissions may be missing\n", k.Kind)
+		}
+	}
+	return rbac.NewResolver(data.RBAC), nil
+}
+
+// printGrants writes the grants as a table to stdout
+func printGrants(grants []rbac.Grant) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SUBJECT\tSCOPE\tVERBS\tRESOURCES\tVIA")
+	for _, g := range grants {
+		resources := strings.Join(append(append([]string{}, g.Rule.Resources...), g.Rule.NonResourceURLs...), ",")
+		if len(g.Rule.ResourceNames) > 0 {
+			resources += " [" + strings.Join(g.Rule.ResourceNames, ",") + "]"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			rbac.SubjectName(g.Subject),
+			excel.PermissionScope(g),
+			strings.Join(g.Rule.Verbs, ","),
+			resources,
+			g.Via())
+	}
+	return w.Flush()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// reportTimestampLayout is the UTC timestamp appended to report names in
+// --output-dir mode. It sorts lexically in chronological order.
+const reportTimestampLayout = "20060102T150405Z"
+
+// timestampedOutputPath places output in dir with a timestamp suffix, e.g.
+// kubeRadar_assessment.xlsx becomes dir/kubeRadar_assessment_20240102T030405Z.xlsx
+func timestampedOutputPath(dir, output string, now time.Time) string {
+	base := filepath.Base(output)
+	ext := filepath.Ext(base)
+	return filepath.Join(dir, fmt.Sprintf("%s_%s%s", strings.TrimSuffix(base, ext), now.UTC().Format(reportTimestampLayout), ext))
+}
+
+// pruneReports keeps the files of the newest keep runs written by
+// timestampedOutputPath for the given output name and format, and removes
+// the rest.
+// Per-cluster files of a multi-context run share their run's timestamp and
+// are kept or removed together.
+func pruneReports(dir, output string, keep int) ([]string, error) {
+	base := filepath.Base(output)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "_"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	runs := make(map[string][]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || filepath.Ext(name) != ext ||
+			len(name) < len(prefix)+len(reportTimestampLayout) {
+			continue
+		}
+		stamp := name[len(prefix) : len(prefix)+len(reportTimestampLayout)]
+		if _, err := time.Parse(reportTimestampLayout, stamp); err != nil {
+			continue
+		}
+		runs[stamp] = append(runs[stamp], filepath.Join(dir, name))
+	}
+
+	stamps := make([]string, 0, len(runs))
+	for stamp := range runs {
+		stamps = append(stamps, stamp)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(stamps)))
+
+	removed := make([]string, 0)
+	for i := keep; i < len(stamps); i++ {
+		for _, path := range runs[stamps[i]] {
+			if err := os.Remove(path); err != nil {
+				return removed, err
+			}
+			removed = append(removed, path)
+		}
+	}
+	return removed, nil
+}